@@ -96,6 +96,15 @@ enum BiomeKind {
     CORN
 }
 
+// edge values are BigInt hex strings, such as "0x0a" or "-0x0a", or null
+// when there is no matching edge
+const decodeValue = (value: string | null): number | null => {
+    if (value === null || value === undefined) {
+        return null;
+    }
+    return BigNumber.from(value).toNumber();
+}
+
 enum Direction {
     NORTH,
     NORTHEAST,
@@ -242,7 +251,7 @@ export default class Demo extends Phaser.Scene {
         const UNDISCOVERED_GRASS = 66;
         const BLOCKING_GRASS = 62;
         const PASSABLE_GRASS = 5;
-        const grassType = (i:number, seed:number): number => {
+        const grassType = (i:number | null, seed:number): number => {
             switch (i) {
                 case null: return 1;
                 case BiomeKind.UNDISCOVERED: return UNDISCOVERED_GRASS;
@@ -357,23 +366,24 @@ export default class Demo extends Phaser.Scene {
                 const x = BigNumber.from(tile.coords[0]).toNumber();
                 const y = BigNumber.from(tile.coords[1]).toNumber();
                 const blk = BigNumber.from(tile.seed?.key || 0).toNumber();
+                const biome = decodeValue(tile.biome);
                 // we use different types of grass to indicate passable/blocking
-                const grass = grassType(tile.biome, blk+x+y);
+                const grass = grassType(biome, blk+x+y);
                 map.putTileAt(grass, x, y, undefined, baseLayer);
                 // then we place something pretty on top to make the tile distinct
-                if (tile.biome === BiomeKind.CORN) {
+                if (biome === BiomeKind.CORN) {
                     const corn = 15;
                     map.putTileAt(corn, x, y, undefined, resourcesLayer);
-                } else if (tile.biome == BiomeKind.BLOCKER) {
+                } else if (biome == BiomeKind.BLOCKER) {
                     const tree = [526,592,649,526,526][(blk+x+y) % 5];
                     map.putTileAt(tree, x, y, undefined, resourcesLayer);
-                } else if (tile.biome == BiomeKind.UNDISCOVERED) {
+                } else if (biome == BiomeKind.UNDISCOVERED) {
                     undiscovered.push({x,y});
                 } else {
                     map.removeTileAt(x, y, undefined, undefined, resourcesLayer);
                 }
                 // resolve any nearby pending tiles that needs resolving
-                if (tile.seed && tile.biome == BiomeKind.UNDISCOVERED) {
+                if (tile.seed && biome == BiomeKind.UNDISCOVERED) {
                     // resolve if nearby
                     if (isNearPlayer(x,y)) {
                         // hint this tile is pending
@@ -494,13 +504,13 @@ export default class Demo extends Phaser.Scene {
                 // update player's sidebar stats info
                 if (isPlayerSeeker) {
                     // score
-                    playerBalance.setText(`Corn collected: ${seeker.cornBalance}`);
+                    playerBalance.setText(`Corn collected: ${decodeValue(seeker.cornBalance) || 0}`);
                 }
             });
 
             // update the leaderboard
             [...state.seekers].sort((a:any,b:any) => {
-                return b.cornBalance - a.cornBalance;
+                return (decodeValue(b.cornBalance) || 0) - (decodeValue(a.cornBalance) || 0);
             }).forEach((seeker:any, i:number) => {
                 if (i > leaders.length-1) {
                     return;
@@ -508,7 +518,7 @@ export default class Demo extends Phaser.Scene {
                 if (!seeker.player) {
                     return;
                 }
-                leaders[i].setText(`${i+1} - ${seeker.player.address.slice(0, 8)} - ${seeker.cornBalance} corns`);
+                leaders[i].setText(`${i+1} - ${seeker.player.address.slice(0, 8)} - ${decodeValue(seeker.cornBalance) || 0} corns`);
             });
 
 
@@ -7,9 +7,13 @@ import (
 
 	"github.com/playmint/ds-node/pkg/api"
 	"github.com/playmint/ds-node/pkg/api/model"
+	"github.com/playmint/ds-node/pkg/client/alchemy"
 	"github.com/playmint/ds-node/pkg/config"
+	"github.com/playmint/ds-node/pkg/datatypes"
+	"github.com/playmint/ds-node/pkg/devchain"
 	"github.com/playmint/ds-node/pkg/indexer"
 	"github.com/playmint/ds-node/pkg/mgmt"
+	"github.com/playmint/ds-node/pkg/scopes"
 	"github.com/playmint/ds-node/pkg/sequencer"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -20,6 +24,9 @@ func Main(ctx context.Context) error {
 	// init logging
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 	debug := flag.Bool("debug", false, "sets log level to debug")
+	flag.StringVar(&config.IndexerRecordPath, "record", config.IndexerRecordPath, "appends every indexed batch of logs to this file as JSONL")
+	flag.BoolVar(&config.DevMode, "dev", config.DevMode, "enables the /dev endpoints on the management server for controlling a local anvil chain")
+	flag.StringVar(&config.IndexerReplayPath, "replay", config.IndexerReplayPath, "indexes logs from a file written by -record instead of the chain")
 	flag.Parse()
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 	if *debug {
@@ -27,12 +34,17 @@ func Main(ctx context.Context) error {
 	}
 	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 
-	// start the management server
-	go func() {
-		if err := mgmt.ListenAndServe(":9090"); err != nil {
-			log.Fatal().Err(err).Str("service", "mgmt").Msg("exited")
-		}
-	}()
+	// load session scope definitions
+	scopeRegistry, err := scopes.Load(config.ScopesConfigPath)
+	if err != nil {
+		return err
+	}
+
+	// load node data type declarations
+	dataTypes, err := datatypes.Load(config.NodeDataTypesPath)
+	if err != nil {
+		return err
+	}
 
 	// configure subscriptions consumer
 	subscriptions, notifications := model.NewSubscriptions()
@@ -44,6 +56,26 @@ func Main(ctx context.Context) error {
 		return err
 	}
 
+	// start the management server
+	mgmtServer := mgmt.Server{}
+	if config.IndexerSnapshotExport {
+		mgmtServer.Snapshotter = idxr
+	}
+	if config.DevMode {
+		// enable dev chain controls
+		devClient, err := alchemy.Dial(config.SequencerProviderHTTP, 1, nil)
+		if err != nil {
+			return err
+		}
+		mgmtServer.DevChain = devchain.New(devClient, config.DevForkURL, idxr)
+		log.Warn().Str("service", "devchain").Msg("enabled")
+	}
+	go func() {
+		if err := mgmtServer.ListenAndServe(":9090"); err != nil {
+			log.Fatal().Err(err).Str("service", "mgmt").Msg("exited")
+		}
+	}()
+
 	// start a sequencer
 	seqr, err := sequencer.NewMemorySequencer(
 		ctx,
@@ -57,7 +89,11 @@ func Main(ctx context.Context) error {
 	}
 
 	// wait for ready
-	<-idxr.Ready()
+	select {
+	case <-idxr.Ready():
+	case err := <-idxr.Failed():
+		return err
+	}
 	log.Info().Str("service", "indexer").Msg("ready")
 	<-seqr.Ready()
 	log.Info().Str("service", "sequencer").Msg("ready")
@@ -66,6 +102,8 @@ func Main(ctx context.Context) error {
 	api := api.Server{
 		Indexer:   idxr,
 		Sequencer: seqr,
+		Scopes:    scopeRegistry,
+		DataTypes: dataTypes,
 	}
 	if err := api.Start(ctx, subscriptions); err != nil {
 		log.Fatal().Err(err).Str("service", "api").Msg("exited")
@@ -20,7 +20,9 @@ import (
 	"github.com/playmint/ds-node/pkg/api/model"
 	"github.com/playmint/ds-node/pkg/api/resolver"
 	"github.com/playmint/ds-node/pkg/config"
+	"github.com/playmint/ds-node/pkg/datatypes"
 	"github.com/playmint/ds-node/pkg/indexer"
+	"github.com/playmint/ds-node/pkg/scopes"
 	"github.com/playmint/ds-node/pkg/sequencer"
 	"github.com/rs/cors"
 )
@@ -28,6 +30,8 @@ import (
 type Server struct {
 	Indexer   indexer.Indexer
 	Sequencer sequencer.Sequencer
+	Scopes    *scopes.Registry
+	DataTypes *datatypes.Registry
 }
 
 func (api *Server) Start(ctx context.Context, subscriptions *model.Subscriptions) error {
@@ -37,6 +41,8 @@ func (api *Server) Start(ctx context.Context, subscriptions *model.Subscriptions
 		Indexer:       api.Indexer,
 		Sequencer:     api.Sequencer,
 		Subscriptions: subscriptions,
+		Scopes:        api.Scopes,
+		DataTypes:     api.DataTypes,
 	}
 
 	// start server
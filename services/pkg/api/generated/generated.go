@@ -41,8 +41,10 @@ type ResolverRoot interface {
 	ActionTransaction() ActionTransactionResolver
 	Game() GameResolver
 	Mutation() MutationResolver
+	NodeData() NodeDataResolver
 	Query() QueryResolver
 	Router() RouterResolver
+	Session() SessionResolver
 	State() StateResolver
 	Subscription() SubscriptionResolver
 }
@@ -84,6 +86,7 @@ type ComplexityRoot struct {
 	BlockEvent struct {
 		Block     func(childComplexity int) int
 		ID        func(childComplexity int) int
+		Rollback  func(childComplexity int) int
 		Sigs      func(childComplexity int) int
 		Simulated func(childComplexity int) int
 	}
@@ -127,12 +130,23 @@ type ComplexityRoot struct {
 		Weight func(childComplexity int) int
 	}
 
+	EdgeConnection struct {
+		Edges      func(childComplexity int) int
+		PageInfo   func(childComplexity int) int
+		TotalCount func(childComplexity int) int
+	}
+
+	EdgeConnectionEdge struct {
+		Cursor func(childComplexity int) int
+		Node   func(childComplexity int) int
+	}
+
 	Game struct {
 		Dispatcher  func(childComplexity int) int
 		ID          func(childComplexity int) int
 		Name        func(childComplexity int) int
 		Router      func(childComplexity int) int
-		State       func(childComplexity int, block *int, simulated *bool) int
+		State       func(childComplexity int, block *int, simulated *bool, finalized *bool) int
 		Subscribers func(childComplexity int) int
 		URL         func(childComplexity int) int
 	}
@@ -145,32 +159,59 @@ type ComplexityRoot struct {
 	}
 
 	Node struct {
-		AllData     func(childComplexity int) int
-		Annotation  func(childComplexity int, name string) int
-		Annotations func(childComplexity int) int
-		Count       func(childComplexity int, match *model.Match) int
-		Data        func(childComplexity int, name string) int
-		Edge        func(childComplexity int, match *model.Match) int
-		Edges       func(childComplexity int, match *model.Match) int
-		ID          func(childComplexity int) int
-		Key         func(childComplexity int) int
-		Keys        func(childComplexity int) int
-		Kind        func(childComplexity int) int
-		Node        func(childComplexity int, match *model.Match) int
-		Nodes       func(childComplexity int, match *model.Match) int
-		Sum         func(childComplexity int, match *model.Match) int
-		Value       func(childComplexity int, match *model.Match) int
+		AllData         func(childComplexity int) int
+		Annotation      func(childComplexity int, name string) int
+		Annotations     func(childComplexity int) int
+		Count           func(childComplexity int, match *model.Match) int
+		Data            func(childComplexity int, name string) int
+		Edge            func(childComplexity int, match *model.Match) int
+		Edges           func(childComplexity int, match *model.Match) int
+		EdgesConnection func(childComplexity int, match *model.Match, first *int, after *string, orderBy *model.EdgeOrder) int
+		ID              func(childComplexity int) int
+		Key             func(childComplexity int) int
+		Keys            func(childComplexity int) int
+		Kind            func(childComplexity int) int
+		Node            func(childComplexity int, match *model.Match) int
+		Nodes           func(childComplexity int, match *model.Match) int
+		Sum             func(childComplexity int, match *model.Match) int
+		Value           func(childComplexity int, match *model.Match) int
+	}
+
+	NodeConnection struct {
+		Edges      func(childComplexity int) int
+		PageInfo   func(childComplexity int) int
+		TotalCount func(childComplexity int) int
+	}
+
+	NodeConnectionEdge struct {
+		Cursor func(childComplexity int) int
+		Node   func(childComplexity int) int
 	}
 
 	NodeData struct {
-		ID    func(childComplexity int) int
-		Name  func(childComplexity int) int
-		Value func(childComplexity int) int
+		Address func(childComplexity int) int
+		Bool    func(childComplexity int) int
+		Bytes   func(childComplexity int) int
+		ID      func(childComplexity int) int
+		Int     func(childComplexity int) int
+		Kind    func(childComplexity int) int
+		Name    func(childComplexity int) int
+		String  func(childComplexity int) int
+		Uint    func(childComplexity int) int
+		Value   func(childComplexity int) int
+	}
+
+	PageInfo struct {
+		EndCursor       func(childComplexity int) int
+		HasNextPage     func(childComplexity int) int
+		HasPreviousPage func(childComplexity int) int
+		StartCursor     func(childComplexity int) int
 	}
 
 	Query struct {
 		Game  func(childComplexity int, id string) int
 		Games func(childComplexity int) int
+		Store func(childComplexity int, name string, key *string) int
 	}
 
 	Router struct {
@@ -185,19 +226,26 @@ type ComplexityRoot struct {
 		Expires func(childComplexity int) int
 		ID      func(childComplexity int) int
 		Owner   func(childComplexity int) int
+		Revoked func(childComplexity int) int
 		Scope   func(childComplexity int) int
+		Status  func(childComplexity int) int
 	}
 
 	SessionScope struct {
+		Bits       func(childComplexity int) int
 		FullAccess func(childComplexity int) int
+		Granted    func(childComplexity int) int
 	}
 
 	State struct {
-		Block     func(childComplexity int) int
-		ID        func(childComplexity int) int
-		Node      func(childComplexity int, match *model.Match) int
-		Nodes     func(childComplexity int, match *model.Match) int
-		Simulated func(childComplexity int) int
+		Block           func(childComplexity int) int
+		Finalized       func(childComplexity int) int
+		ID              func(childComplexity int) int
+		Node            func(childComplexity int, match *model.Match) int
+		Nodes           func(childComplexity int, match *model.Match) int
+		NodesConnection func(childComplexity int, match *model.Match, first *int, after *string, orderBy *model.NodeOrder) int
+		Path            func(childComplexity int, from string, to string, match *model.Match, weighted *bool) int
+		Simulated       func(childComplexity int) int
 	}
 
 	Subscription struct {
@@ -211,7 +259,7 @@ type ActionTransactionResolver interface {
 	Nonce(ctx context.Context, obj *model.ActionTransaction) (int, error)
 }
 type GameResolver interface {
-	State(ctx context.Context, obj *model.Game, block *int, simulated *bool) (*model.State, error)
+	State(ctx context.Context, obj *model.Game, block *int, simulated *bool, finalized *bool) (*model.State, error)
 
 	Subscribers(ctx context.Context, obj *model.Game) (int, error)
 }
@@ -221,9 +269,19 @@ type MutationResolver interface {
 	Signout(ctx context.Context, gameID string, session string, authorization string) (bool, error)
 	Dispatch(ctx context.Context, gameID string, actions []string, authorization string, nonce int, optimistic bool) (*model.ActionTransaction, error)
 }
+type NodeDataResolver interface {
+	Kind(ctx context.Context, obj *model.NodeData) (*model.AttributeKind, error)
+	Int(ctx context.Context, obj *model.NodeData) (*big.Int, error)
+	Uint(ctx context.Context, obj *model.NodeData) (*big.Int, error)
+	Bool(ctx context.Context, obj *model.NodeData) (*bool, error)
+	Address(ctx context.Context, obj *model.NodeData) (*string, error)
+	String(ctx context.Context, obj *model.NodeData) (*string, error)
+	Bytes(ctx context.Context, obj *model.NodeData) (*string, error)
+}
 type QueryResolver interface {
 	Game(ctx context.Context, id string) (*model.Game, error)
 	Games(ctx context.Context) ([]*model.Game, error)
+	Store(ctx context.Context, name string, key *string) (interface{}, error)
 }
 type RouterResolver interface {
 	Sessions(ctx context.Context, obj *model.Router, owner *string) ([]*model.Session, error)
@@ -231,11 +289,16 @@ type RouterResolver interface {
 	Transactions(ctx context.Context, obj *model.Router, owner *string, status []model.ActionTransactionStatus) ([]*model.ActionTransaction, error)
 	Transaction(ctx context.Context, obj *model.Router, id string) (*model.ActionTransaction, error)
 }
+type SessionResolver interface {
+	Scope(ctx context.Context, obj *model.Session) (*model.SessionScope, error)
+}
 type StateResolver interface {
 	Block(ctx context.Context, obj *model.State) (int, error)
 
 	Nodes(ctx context.Context, obj *model.State, match *model.Match) ([]*model.Node, error)
+	NodesConnection(ctx context.Context, obj *model.State, match *model.Match, first *int, after *string, orderBy *model.NodeOrder) (*model.NodeConnection, error)
 	Node(ctx context.Context, obj *model.State, match *model.Match) (*model.Node, error)
+	Path(ctx context.Context, obj *model.State, from string, to string, match *model.Match, weighted *bool) ([]*model.Edge, error)
 }
 type SubscriptionResolver interface {
 	Events(ctx context.Context, gameID string, simulated *bool) (<-chan model.Event, error)
@@ -398,6 +461,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.BlockEvent.ID(childComplexity), true
 
+	case "BlockEvent.rollback":
+		if e.complexity.BlockEvent.Rollback == nil {
+			break
+		}
+
+		return e.complexity.BlockEvent.Rollback(childComplexity), true
+
 	case "BlockEvent.sigs":
 		if e.complexity.BlockEvent.Sigs == nil {
 			break
@@ -580,6 +650,41 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Edge.Weight(childComplexity), true
 
+	case "EdgeConnection.edges":
+		if e.complexity.EdgeConnection.Edges == nil {
+			break
+		}
+
+		return e.complexity.EdgeConnection.Edges(childComplexity), true
+
+	case "EdgeConnection.pageInfo":
+		if e.complexity.EdgeConnection.PageInfo == nil {
+			break
+		}
+
+		return e.complexity.EdgeConnection.PageInfo(childComplexity), true
+
+	case "EdgeConnection.totalCount":
+		if e.complexity.EdgeConnection.TotalCount == nil {
+			break
+		}
+
+		return e.complexity.EdgeConnection.TotalCount(childComplexity), true
+
+	case "EdgeConnectionEdge.cursor":
+		if e.complexity.EdgeConnectionEdge.Cursor == nil {
+			break
+		}
+
+		return e.complexity.EdgeConnectionEdge.Cursor(childComplexity), true
+
+	case "EdgeConnectionEdge.node":
+		if e.complexity.EdgeConnectionEdge.Node == nil {
+			break
+		}
+
+		return e.complexity.EdgeConnectionEdge.Node(childComplexity), true
+
 	case "Game.dispatcher":
 		if e.complexity.Game.Dispatcher == nil {
 			break
@@ -618,7 +723,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Game.State(childComplexity, args["block"].(*int), args["simulated"].(*bool)), true
+		return e.complexity.Game.State(childComplexity, args["block"].(*int), args["simulated"].(*bool), args["finalized"].(*bool)), true
 
 	case "Game.subscribers":
 		if e.complexity.Game.Subscribers == nil {
@@ -756,6 +861,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Node.Edges(childComplexity, args["match"].(*model.Match)), true
 
+	case "Node.edgesConnection":
+		if e.complexity.Node.EdgesConnection == nil {
+			break
+		}
+
+		args, err := ec.field_Node_edgesConnection_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Node.EdgesConnection(childComplexity, args["match"].(*model.Match), args["first"].(*int), args["after"].(*string), args["orderBy"].(*model.EdgeOrder)), true
+
 	case "Node.id":
 		if e.complexity.Node.ID == nil {
 			break
@@ -832,6 +949,62 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Node.Value(childComplexity, args["match"].(*model.Match)), true
 
+	case "NodeConnection.edges":
+		if e.complexity.NodeConnection.Edges == nil {
+			break
+		}
+
+		return e.complexity.NodeConnection.Edges(childComplexity), true
+
+	case "NodeConnection.pageInfo":
+		if e.complexity.NodeConnection.PageInfo == nil {
+			break
+		}
+
+		return e.complexity.NodeConnection.PageInfo(childComplexity), true
+
+	case "NodeConnection.totalCount":
+		if e.complexity.NodeConnection.TotalCount == nil {
+			break
+		}
+
+		return e.complexity.NodeConnection.TotalCount(childComplexity), true
+
+	case "NodeConnectionEdge.cursor":
+		if e.complexity.NodeConnectionEdge.Cursor == nil {
+			break
+		}
+
+		return e.complexity.NodeConnectionEdge.Cursor(childComplexity), true
+
+	case "NodeConnectionEdge.node":
+		if e.complexity.NodeConnectionEdge.Node == nil {
+			break
+		}
+
+		return e.complexity.NodeConnectionEdge.Node(childComplexity), true
+
+	case "NodeData.address":
+		if e.complexity.NodeData.Address == nil {
+			break
+		}
+
+		return e.complexity.NodeData.Address(childComplexity), true
+
+	case "NodeData.bool":
+		if e.complexity.NodeData.Bool == nil {
+			break
+		}
+
+		return e.complexity.NodeData.Bool(childComplexity), true
+
+	case "NodeData.bytes":
+		if e.complexity.NodeData.Bytes == nil {
+			break
+		}
+
+		return e.complexity.NodeData.Bytes(childComplexity), true
+
 	case "NodeData.id":
 		if e.complexity.NodeData.ID == nil {
 			break
@@ -839,6 +1012,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.NodeData.ID(childComplexity), true
 
+	case "NodeData.int":
+		if e.complexity.NodeData.Int == nil {
+			break
+		}
+
+		return e.complexity.NodeData.Int(childComplexity), true
+
+	case "NodeData.kind":
+		if e.complexity.NodeData.Kind == nil {
+			break
+		}
+
+		return e.complexity.NodeData.Kind(childComplexity), true
+
 	case "NodeData.name":
 		if e.complexity.NodeData.Name == nil {
 			break
@@ -846,6 +1033,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.NodeData.Name(childComplexity), true
 
+	case "NodeData.string":
+		if e.complexity.NodeData.String == nil {
+			break
+		}
+
+		return e.complexity.NodeData.String(childComplexity), true
+
+	case "NodeData.uint":
+		if e.complexity.NodeData.Uint == nil {
+			break
+		}
+
+		return e.complexity.NodeData.Uint(childComplexity), true
+
 	case "NodeData.value":
 		if e.complexity.NodeData.Value == nil {
 			break
@@ -853,6 +1054,34 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.NodeData.Value(childComplexity), true
 
+	case "PageInfo.endCursor":
+		if e.complexity.PageInfo.EndCursor == nil {
+			break
+		}
+
+		return e.complexity.PageInfo.EndCursor(childComplexity), true
+
+	case "PageInfo.hasNextPage":
+		if e.complexity.PageInfo.HasNextPage == nil {
+			break
+		}
+
+		return e.complexity.PageInfo.HasNextPage(childComplexity), true
+
+	case "PageInfo.hasPreviousPage":
+		if e.complexity.PageInfo.HasPreviousPage == nil {
+			break
+		}
+
+		return e.complexity.PageInfo.HasPreviousPage(childComplexity), true
+
+	case "PageInfo.startCursor":
+		if e.complexity.PageInfo.StartCursor == nil {
+			break
+		}
+
+		return e.complexity.PageInfo.StartCursor(childComplexity), true
+
 	case "Query.game":
 		if e.complexity.Query.Game == nil {
 			break
@@ -872,6 +1101,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.Games(childComplexity), true
 
+	case "Query.store":
+		if e.complexity.Query.Store == nil {
+			break
+		}
+
+		args, err := ec.field_Query_store_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Store(childComplexity, args["name"].(string), args["key"].(*string)), true
+
 	case "Router.id":
 		if e.complexity.Router.ID == nil {
 			break
@@ -948,6 +1189,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Session.Owner(childComplexity), true
 
+	case "Session.revoked":
+		if e.complexity.Session.Revoked == nil {
+			break
+		}
+
+		return e.complexity.Session.Revoked(childComplexity), true
+
 	case "Session.scope":
 		if e.complexity.Session.Scope == nil {
 			break
@@ -955,6 +1203,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Session.Scope(childComplexity), true
 
+	case "Session.status":
+		if e.complexity.Session.Status == nil {
+			break
+		}
+
+		return e.complexity.Session.Status(childComplexity), true
+
+	case "SessionScope.bits":
+		if e.complexity.SessionScope.Bits == nil {
+			break
+		}
+
+		return e.complexity.SessionScope.Bits(childComplexity), true
+
 	case "SessionScope.FullAccess":
 		if e.complexity.SessionScope.FullAccess == nil {
 			break
@@ -962,6 +1224,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.SessionScope.FullAccess(childComplexity), true
 
+	case "SessionScope.granted":
+		if e.complexity.SessionScope.Granted == nil {
+			break
+		}
+
+		return e.complexity.SessionScope.Granted(childComplexity), true
+
 	case "State.block":
 		if e.complexity.State.Block == nil {
 			break
@@ -969,6 +1238,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.State.Block(childComplexity), true
 
+	case "State.finalized":
+		if e.complexity.State.Finalized == nil {
+			break
+		}
+
+		return e.complexity.State.Finalized(childComplexity), true
+
 	case "State.id":
 		if e.complexity.State.ID == nil {
 			break
@@ -1000,6 +1276,30 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.State.Nodes(childComplexity, args["match"].(*model.Match)), true
 
+	case "State.nodesConnection":
+		if e.complexity.State.NodesConnection == nil {
+			break
+		}
+
+		args, err := ec.field_State_nodesConnection_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.State.NodesConnection(childComplexity, args["match"].(*model.Match), args["first"].(*int), args["after"].(*string), args["orderBy"].(*model.NodeOrder)), true
+
+	case "State.path":
+		if e.complexity.State.Path == nil {
+			break
+		}
+
+		args, err := ec.field_State_path_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.State.Path(childComplexity, args["from"].(string), args["to"].(string), args["match"].(*model.Match), args["weighted"].(*bool)), true
+
 	case "State.simulated":
 		if e.complexity.State.Simulated == nil {
 			break
@@ -1174,7 +1474,7 @@ type Game {
 	url: String!
 
 	dispatcher: Dispatcher!
-	state(block: Int, simulated: Boolean): State!
+	state(block: Int, simulated: Boolean, finalized: Boolean): State! # block returns the state as of that block if it is still within the retained history
 	router: Router!
 	subscribers: Int!
 }
@@ -1209,9 +1509,12 @@ type Game {
 }
 `, BuiltIn: false},
 	{Name: "schema/query.graphqls", Input: `
+scalar Any
+
 type Query {
 	game(id: ID!): Game!
 	games: [Game!]!
+	store(name: String!, key: String): Any # data from a custom store registered with stores.Register
 }
 `, BuiltIn: false},
 	{Name: "schema/router.graphqls", Input: `enum ActionTransactionStatus {
@@ -1240,15 +1543,25 @@ type ActionTransaction {
 	nonce: Int!
 }
 
+enum SessionStatus {
+	ACTIVE
+	EXPIRED
+	REVOKED
+}
+
 type SessionScope {
-	FullAccess: Boolean! # haven't given permissions much thought yet so this is just illustrative
+	FullAccess: Boolean!
+	granted: [String!]! # names of the scopes granted to the session, see scopes.Registry
+	bits: String! # the raw scopes bitmask the session was created with as hex
 }
 
 type Session {
 	id: ID! # this id is the session public key address
 	owner: String! # the address this session is acting as
-	scope: SessionScope!
+	scope: SessionScope! @goField(forceResolver: true)
 	expires: Int! # the block when this session becomes invalid
+	revoked: Boolean! # true once the owner has revoked the session, revoked sessions are removed from the index
+	status: SessionStatus! # expired sessions are removed from the index after a grace period
 }
 
 type Router {
@@ -1262,7 +1575,11 @@ type Router {
 	transaction(id: ID!): ActionTransaction @goField(forceResolver: true)
 }
 `, BuiltIn: false},
-	{Name: "schema/state.graphqls", Input: `scalar BigInt
+	{Name: "schema/state.graphqls", Input: `"""
+a 0x prefixed hex encoded integer of any size, negative values are prefixed
+with - (eg "-0x01")
+"""
+scalar BigInt
 
 """
 match condition for traversing/filtering the graph.
@@ -1292,9 +1609,33 @@ input Match {
 
 	match(via: ["HAS_RED", "HAS_BLUE"]) would return B,C,Y,Z
 	match(via: ["HAS_RED", "HAS_BLUE"], has: ["HAS_RED"]) would return B,Z
+
+	when traversing, the edge that reached the node must match one of the
+	rels, but edges that do not are still followed. when matching nodes
+	directly (eg State.nodes) the node must have an edge of its own that
+	matches one of the rels, with dir relative to that node.
 	"""
 	has: [RelMatch!]
 
+	"""
+	data only matches nodes whose node data satisfies all of the conditions.
+	like has, edges to nodes that do not match are still followed.
+	"""
+	data: [DataMatch!]
+
+	"""
+	annotations only matches nodes whose annotations satisfy all of the
+	conditions. like has, edges to nodes that do not match are still followed.
+	"""
+	annotations: [AnnotationMatch!]
+
+	"""
+	weight only matches edges with a weight within the bounds, decoded the
+	same way as Edge.weight. like has, edges that do not match are still
+	followed. it is ignored when matching nodes directly (eg State.nodes).
+	"""
+	weight: BigIntMatch
+
 	"""
 	` + "`" + `limit` + "`" + ` stops matches after that many edges have been collected
 	"""
@@ -1307,6 +1648,56 @@ input Match {
 	maxDepth: Int
 }
 
+"""
+BigIntMatch compares a value against each bound that is set, all of them must
+hold. combine a lower and upper bound to match a range, eg {gte: "0x1", lt: "0xa"}
+"""
+input BigIntMatch {
+	eq: BigInt
+	gt: BigInt
+	gte: BigInt
+	lt: BigInt
+	lte: BigInt
+}
+
+"""
+DataMatch is a condition on the node data stored against a label.
+
+data values are 32 bytes and are compared as unsigned integers, or as sign
+extended int256 values when signed is true. unset data compares as zero, the
+same as reading it on-chain.
+"""
+input DataMatch {
+	name: String!
+
+	"""
+	if true only match nodes with data set for the label, if false only nodes
+	without
+	"""
+	exists: Boolean
+
+	value: BigIntMatch
+
+	signed: Boolean
+}
+
+"""
+AnnotationMatch is a condition on the annotation stored against a label.
+"""
+input AnnotationMatch {
+	name: String!
+
+	"""
+	if true only match nodes with the annotation, if false only nodes without
+	"""
+	exists: Boolean
+
+	"""
+	only match nodes where the annotation is exactly this value
+	"""
+	value: String
+}
+
 """
 RelMatchDirection indicates a direction of the relationship to match.  Edges
 are directional (they have a src node on one end and a dst node on the other)
@@ -1339,14 +1730,45 @@ type State {
 	block: Int! @goField(forceResolver: true) # block number of last seen update
 	simulated: Boolean!
 	"""
-	nodes returns any nodes that match the Match filter.
+	finalized state only includes blocks that are deep enough (or tagged as
+	finalized by the chain) that they can no longer be reorged
+	"""
+	finalized: Boolean!
+	"""
+	nodes returns any nodes that match the Match filter, ordered by id.
 	"""
 	nodes(match: Match): [Node!]! @goField(forceResolver: true)
 
+	"""
+	nodesConnection pages through the nodes that match the Match filter.
+	` + "`" + `first` + "`" + ` limits the page size and ` + "`" + `after` + "`" + ` continues from the endCursor of
+	the previous page, defaults to every node ordered by id. match.limit
+	keeps only the first nodes in that order.
+	"""
+	nodesConnection(match: Match, first: Int, after: String, orderBy: NodeOrder): NodeConnection! @goField(forceResolver: true)
+
 	"""
 	node returns the first node that mates the Match filter.
 	"""
 	node(match: Match): Node @goField(forceResolver: true)
+
+	"""
+	path returns the shortest route from one node to another as the edges to
+	follow in order, or null if there is no route. an empty list is returned
+	when from and to are the same node.
+
+	only edges allowed by the match's ` + "`" + `via` + "`" + ` rels (and their directions) are
+	followed, and only nodes allowed by its ` + "`" + `ids` + "`" + ` and ` + "`" + `kinds` + "`" + ` are passed
+	through, the ` + "`" + `to` + "`" + ` node is always allowed. ` + "`" + `maxDepth` + "`" + ` works as it does
+	when traversing, so the path has at most maxDepth+1 edges, unlimited when
+	unset. the other match conditions are ignored.
+
+	by default the path with the fewest edges is returned, with ` + "`" + `weighted` + "`" + ` it
+	is the path with the lowest total edge weight instead (decoded the same
+	way as Edge.weight), it is an error for the search to meet a negative
+	weight.
+	"""
+	path(from: ID!, to: ID!, match: Match, weighted: Boolean): [Edge!] @goField(forceResolver: true)
 }
 
 type Node {
@@ -1413,6 +1835,14 @@ type Node {
 	"""
 	edges(match: Match): [Edge!]!
 
+	"""
+	edgesConnection pages through the edges that ` + "`" + `edges` + "`" + ` would return.
+	` + "`" + `first` + "`" + ` limits the page size and ` + "`" + `after` + "`" + ` continues from the endCursor of
+	the previous page, defaults to every edge ordered by id. match.limit
+	keeps only the first edges in that order.
+	"""
+	edgesConnection(match: Match, first: Int, after: String, orderBy: EdgeOrder): EdgeConnection!
+
 	"""
 	same as ` + "`" + `edges` + "`" + `, but only returns the first match
 	"""
@@ -1422,13 +1852,13 @@ type Node {
 	` + "`" + `value` + "`" + ` operates exactly as ` + "`" + `edge` + "`" + ` but instead of returning the Edge it
 	returns the weight value of that edge.
 	"""
-	value(match: Match): Int
+	value(match: Match): BigInt
 
 	"""
 	` + "`" + `sum` + "`" + ` operates like ` + "`" + `edges` + "`" + ` but instead of returning the edges, it sums up
 	all the weights of the matched edges.
 	"""
-	sum(match: Match): Int!
+	sum(match: Match): BigInt!
 
 	"""
 	` + "`" + `count` + "`" + ` operates like ` + "`" + `edges` + "`" + ` but instead of returning the edges, it
@@ -1473,9 +1903,11 @@ type Edge {
 	dst: Node!
 
 	"""
-	` + "`" + `weight` + "`" + ` is the value stored in the edge
+	` + "`" + `weight` + "`" + ` is the value stored in the edge, decoded according to the
+	WeightKind the rel was registered with. INT64 weights may be negative,
+	other kinds are the unsigned uint160 value.
 	"""
-	weight: Int!
+	weight: BigInt!
 
 	"""
 	` + "`" + `key` + "`" + ` is the numeric key that uniquely identifies this edge from other edges
@@ -1557,30 +1989,163 @@ node data is an on-chain 32byte value stored as a key value pair for a given nod
 type NodeData {
 	id: ID!
 	name: String!
+
+	"""
+	` + "`" + `value` + "`" + ` is the raw 32 bytes as hex
+	"""
 	value: String!
-}
-`, BuiltIn: false},
-	{Name: "schema/subscriptions.graphqls", Input: `interface Event {
-	id: ID!
-}
 
-type BlockEvent implements Event {
-	id: ID!
-	block: Int!
-	sigs: [String!]!
-	simulated: Boolean!
-}
+	"""
+	` + "`" + `kind` + "`" + ` is the type declared for this label on the node's kind in the node
+	data types config (NODE_DATA_TYPES_PATH), or null if none is declared.
+	Only the decoded field below matching the kind is set.
+	"""
+	kind: AttributeKind @goField(forceResolver: true)
 
-type Subscription {
-	events(gameID: ID!, simulated: Boolean): Event!
-	transaction(gameID: ID!, owner: String): ActionTransaction!
-	session(gameID: ID!, owner: String): Session!
+	"""
+	set for INT8 to INT256 and INT kinds
+	"""
+	int: BigInt @goField(forceResolver: true)
+
+	"""
+	set for UINT8 to UINT256 kinds
+	"""
+	uint: BigInt @goField(forceResolver: true)
+
+	"""
+	set for BOOL kinds
+	"""
+	bool: Boolean @goField(forceResolver: true)
+
+	"""
+	set for ADDRESS kinds
+	"""
+	address: String @goField(forceResolver: true)
+
+	"""
+	set for STRING kinds, trailing zero bytes are removed
+	"""
+	string: String @goField(forceResolver: true)
+
+	"""
+	set for BYTES and BYTES4 kinds as hex
+	"""
+	bytes: String @goField(forceResolver: true)
 }
-`, BuiltIn: false},
+
+enum OrderDirection {
+	ASC
+	DESC
 }
-var parsedSchema = gqlparser.MustLoadSchema(sources...)
 
-// endregion ************************** generated!.gotpl **************************
+enum NodeOrderField {
+	ID
+	"""
+	the kind name of the node
+	"""
+	KIND
+}
+
+enum EdgeOrderField {
+	"""
+	the id of the edge, then the direction it was followed
+	"""
+	ID
+	"""
+	the kind name of the node at the other end of the edge
+	"""
+	KIND
+	WEIGHT
+	KEY
+}
+
+"""
+NodeOrder sorts nodes by the field, ties are broken by id so the order is
+always the same for the same graph
+"""
+input NodeOrder {
+	field: NodeOrderField!
+	direction: OrderDirection
+}
+
+"""
+EdgeOrder sorts edges by the field, ties are broken by id so the order is
+always the same for the same graph
+"""
+input EdgeOrder {
+	field: EdgeOrderField!
+	direction: OrderDirection
+}
+
+"""
+PageInfo describes a page of a connection, pass endCursor as ` + "`" + `after` + "`" + ` to fetch
+the next page.
+"""
+type PageInfo {
+	hasNextPage: Boolean!
+	hasPreviousPage: Boolean!
+	startCursor: String
+	endCursor: String
+}
+
+"""
+NodeConnection is a page of nodes. cursors hold the position of a node in
+the order rather than the node itself, so paging continues from the same
+place even if that node is removed between requests.
+"""
+type NodeConnection {
+	edges: [NodeConnectionEdge!]!
+	pageInfo: PageInfo!
+	"""
+	the number of nodes that matched, across every page
+	"""
+	totalCount: Int!
+}
+
+type NodeConnectionEdge {
+	cursor: String!
+	node: Node!
+}
+
+"""
+EdgeConnection is a page of edges, see NodeConnection.
+"""
+type EdgeConnection {
+	edges: [EdgeConnectionEdge!]!
+	pageInfo: PageInfo!
+	"""
+	the number of edges that matched, across every page
+	"""
+	totalCount: Int!
+}
+
+type EdgeConnectionEdge {
+	cursor: String!
+	node: Edge!
+}
+`, BuiltIn: false},
+	{Name: "schema/subscriptions.graphqls", Input: `interface Event {
+	id: ID!
+}
+
+type BlockEvent implements Event {
+	id: ID!
+	block: Int!
+	sigs: [String!]!
+	simulated: Boolean!
+	rollback: Boolean! # true if state was rewound to an earlier block due to a chain reorg
+}
+
+type Subscription {
+	events(gameID: ID!, simulated: Boolean): Event!
+	transaction(gameID: ID!, owner: String): ActionTransaction!
+	session(gameID: ID!, owner: String): Session!
+}
+`, BuiltIn: false},
+}
+var parsedSchema = gqlparser.MustLoadSchema(sources...)
+
+// endregion ************************** generated!.gotpl **************************
 
 // region    ***************************** args.gotpl *****************************
 
@@ -1605,6 +2170,15 @@ func (ec *executionContext) field_Game_state_args(ctx context.Context, rawArgs m
 		}
 	}
 	args["simulated"] = arg1
+	var arg2 *bool
+	if tmp, ok := rawArgs["finalized"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("finalized"))
+		arg2, err = ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["finalized"] = arg2
 	return args, nil
 }
 
@@ -1827,6 +2401,48 @@ func (ec *executionContext) field_Node_edge_args(ctx context.Context, rawArgs ma
 	return args, nil
 }
 
+func (ec *executionContext) field_Node_edgesConnection_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *model.Match
+	if tmp, ok := rawArgs["match"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("match"))
+		arg0, err = ec.unmarshalOMatch2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐMatch(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["match"] = arg0
+	var arg1 *int
+	if tmp, ok := rawArgs["first"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("first"))
+		arg1, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["first"] = arg1
+	var arg2 *string
+	if tmp, ok := rawArgs["after"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
+		arg2, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["after"] = arg2
+	var arg3 *model.EdgeOrder
+	if tmp, ok := rawArgs["orderBy"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("orderBy"))
+		arg3, err = ec.unmarshalOEdgeOrder2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeOrder(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["orderBy"] = arg3
+	return args, nil
+}
+
 func (ec *executionContext) field_Node_edges_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1932,6 +2548,30 @@ func (ec *executionContext) field_Query_game_args(ctx context.Context, rawArgs m
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_store_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["key"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("key"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["key"] = arg1
+	return args, nil
+}
+
 func (ec *executionContext) field_Router_session_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -2016,6 +2656,48 @@ func (ec *executionContext) field_State_node_args(ctx context.Context, rawArgs m
 	return args, nil
 }
 
+func (ec *executionContext) field_State_nodesConnection_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *model.Match
+	if tmp, ok := rawArgs["match"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("match"))
+		arg0, err = ec.unmarshalOMatch2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐMatch(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["match"] = arg0
+	var arg1 *int
+	if tmp, ok := rawArgs["first"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("first"))
+		arg1, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["first"] = arg1
+	var arg2 *string
+	if tmp, ok := rawArgs["after"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
+		arg2, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["after"] = arg2
+	var arg3 *model.NodeOrder
+	if tmp, ok := rawArgs["orderBy"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("orderBy"))
+		arg3, err = ec.unmarshalONodeOrder2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeOrder(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["orderBy"] = arg3
+	return args, nil
+}
+
 func (ec *executionContext) field_State_nodes_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -2031,6 +2713,48 @@ func (ec *executionContext) field_State_nodes_args(ctx context.Context, rawArgs
 	return args, nil
 }
 
+func (ec *executionContext) field_State_path_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["from"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("from"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["from"] = arg0
+	var arg1 string
+	if tmp, ok := rawArgs["to"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("to"))
+		arg1, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["to"] = arg1
+	var arg2 *model.Match
+	if tmp, ok := rawArgs["match"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("match"))
+		arg2, err = ec.unmarshalOMatch2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐMatch(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["match"] = arg2
+	var arg3 *bool
+	if tmp, ok := rawArgs["weighted"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("weighted"))
+		arg3, err = ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["weighted"] = arg3
+	return args, nil
+}
+
 func (ec *executionContext) field_Subscription_events_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -2905,6 +3629,41 @@ func (ec *executionContext) _BlockEvent_simulated(ctx context.Context, field gra
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _BlockEvent_rollback(ctx context.Context, field graphql.CollectedField, obj *model.BlockEvent) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "BlockEvent",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Rollback, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _ContractConfig_name(ctx context.Context, field graphql.CollectedField, obj *model.ContractConfig) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -3635,9 +4394,9 @@ func (ec *executionContext) _Edge_weight(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*big.Int)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNBigInt2ᚖmathᚋbigᚐInt(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) _Edge_key(ctx context.Context, field graphql.CollectedField, obj *model.Edge) (ret graphql.Marshaler) {
@@ -3745,7 +4504,7 @@ func (ec *executionContext) _Edge_dir(ctx context.Context, field graphql.Collect
 	return ec.marshalNRelMatchDirection2githubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐRelMatchDirection(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Game_id(ctx context.Context, field graphql.CollectedField, obj *model.Game) (ret graphql.Marshaler) {
+func (ec *executionContext) _EdgeConnection_edges(ctx context.Context, field graphql.CollectedField, obj *model.EdgeConnection) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3753,7 +4512,7 @@ func (ec *executionContext) _Game_id(ctx context.Context, field graphql.Collecte
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Game",
+		Object:     "EdgeConnection",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -3763,7 +4522,7 @@ func (ec *executionContext) _Game_id(ctx context.Context, field graphql.Collecte
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Edges, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3775,12 +4534,12 @@ func (ec *executionContext) _Game_id(ctx context.Context, field graphql.Collecte
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*model.EdgeConnectionEdge)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNEdgeConnectionEdge2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeConnectionEdgeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Game_name(ctx context.Context, field graphql.CollectedField, obj *model.Game) (ret graphql.Marshaler) {
+func (ec *executionContext) _EdgeConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *model.EdgeConnection) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3788,7 +4547,7 @@ func (ec *executionContext) _Game_name(ctx context.Context, field graphql.Collec
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Game",
+		Object:     "EdgeConnection",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -3798,7 +4557,7 @@ func (ec *executionContext) _Game_name(ctx context.Context, field graphql.Collec
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.PageInfo, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3810,12 +4569,12 @@ func (ec *executionContext) _Game_name(ctx context.Context, field graphql.Collec
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*model.PageInfo)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNPageInfo2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐPageInfo(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Game_url(ctx context.Context, field graphql.CollectedField, obj *model.Game) (ret graphql.Marshaler) {
+func (ec *executionContext) _EdgeConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *model.EdgeConnection) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3823,7 +4582,7 @@ func (ec *executionContext) _Game_url(ctx context.Context, field graphql.Collect
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Game",
+		Object:     "EdgeConnection",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -3833,7 +4592,7 @@ func (ec *executionContext) _Game_url(ctx context.Context, field graphql.Collect
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.URL, nil
+		return obj.TotalCount, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3845,12 +4604,12 @@ func (ec *executionContext) _Game_url(ctx context.Context, field graphql.Collect
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Game_dispatcher(ctx context.Context, field graphql.CollectedField, obj *model.Game) (ret graphql.Marshaler) {
+func (ec *executionContext) _EdgeConnectionEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *model.EdgeConnectionEdge) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3858,17 +4617,17 @@ func (ec *executionContext) _Game_dispatcher(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Game",
+		Object:     "EdgeConnectionEdge",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Dispatcher(), nil
+		return obj.Cursor, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3880,12 +4639,12 @@ func (ec *executionContext) _Game_dispatcher(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Dispatcher)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNDispatcher2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐDispatcher(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Game_state(ctx context.Context, field graphql.CollectedField, obj *model.Game) (ret graphql.Marshaler) {
+func (ec *executionContext) _EdgeConnectionEdge_node(ctx context.Context, field graphql.CollectedField, obj *model.EdgeConnectionEdge) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3893,24 +4652,17 @@ func (ec *executionContext) _Game_state(ctx context.Context, field graphql.Colle
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Game",
+		Object:     "EdgeConnectionEdge",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Game_state_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Game().State(rctx, obj, args["block"].(*int), args["simulated"].(*bool))
+		return obj.Node, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3922,12 +4674,12 @@ func (ec *executionContext) _Game_state(ctx context.Context, field graphql.Colle
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.State)
+	res := resTmp.(*model.Edge)
 	fc.Result = res
-	return ec.marshalNState2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐState(ctx, field.Selections, res)
+	return ec.marshalNEdge2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdge(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Game_router(ctx context.Context, field graphql.CollectedField, obj *model.Game) (ret graphql.Marshaler) {
+func (ec *executionContext) _Game_id(ctx context.Context, field graphql.CollectedField, obj *model.Game) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3938,14 +4690,14 @@ func (ec *executionContext) _Game_router(ctx context.Context, field graphql.Coll
 		Object:     "Game",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Router(), nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3957,12 +4709,12 @@ func (ec *executionContext) _Game_router(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Router)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNRouter2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐRouter(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Game_subscribers(ctx context.Context, field graphql.CollectedField, obj *model.Game) (ret graphql.Marshaler) {
+func (ec *executionContext) _Game_name(ctx context.Context, field graphql.CollectedField, obj *model.Game) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3973,14 +4725,14 @@ func (ec *executionContext) _Game_subscribers(ctx context.Context, field graphql
 		Object:     "Game",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Game().Subscribers(rctx, obj)
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3992,7 +4744,189 @@ func (ec *executionContext) _Game_subscribers(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Game_url(ctx context.Context, field graphql.CollectedField, obj *model.Game) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Game",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.URL, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Game_dispatcher(ctx context.Context, field graphql.CollectedField, obj *model.Game) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Game",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Dispatcher(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Dispatcher)
+	fc.Result = res
+	return ec.marshalNDispatcher2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐDispatcher(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Game_state(ctx context.Context, field graphql.CollectedField, obj *model.Game) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Game",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Game_state_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Game().State(rctx, obj, args["block"].(*int), args["simulated"].(*bool), args["finalized"].(*bool))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.State)
+	fc.Result = res
+	return ec.marshalNState2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐState(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Game_router(ctx context.Context, field graphql.CollectedField, obj *model.Game) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Game",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Router(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Router)
+	fc.Result = res
+	return ec.marshalNRouter2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐRouter(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Game_subscribers(ctx context.Context, field graphql.CollectedField, obj *model.Game) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Game",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Game().Subscribers(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
 	fc.Result = res
 	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
@@ -4573,6 +5507,48 @@ func (ec *executionContext) _Node_edges(ctx context.Context, field graphql.Colle
 	return ec.marshalNEdge2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeᚄ(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _Node_edgesConnection(ctx context.Context, field graphql.CollectedField, obj *model.Node) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Node",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Node_edgesConnection_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EdgesConnection(args["match"].(*model.Match), args["first"].(*int), args["after"].(*string), args["orderBy"].(*model.EdgeOrder))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.EdgeConnection)
+	fc.Result = res
+	return ec.marshalNEdgeConnection2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeConnection(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _Node_edge(ctx context.Context, field graphql.CollectedField, obj *model.Node) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -4646,9 +5622,9 @@ func (ec *executionContext) _Node_value(ctx context.Context, field graphql.Colle
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*big.Int)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalOBigInt2ᚖmathᚋbigᚐInt(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) _Node_sum(ctx context.Context, field graphql.CollectedField, obj *model.Node) (ret graphql.Marshaler) {
@@ -4688,9 +5664,9 @@ func (ec *executionContext) _Node_sum(ctx context.Context, field graphql.Collect
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*big.Int)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNBigInt2ᚖmathᚋbigᚐInt(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) _Node_count(ctx context.Context, field graphql.CollectedField, obj *model.Node) (ret graphql.Marshaler) {
@@ -4735,7 +5711,7 @@ func (ec *executionContext) _Node_count(ctx context.Context, field graphql.Colle
 	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _NodeData_id(ctx context.Context, field graphql.CollectedField, obj *model.NodeData) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeConnection_edges(ctx context.Context, field graphql.CollectedField, obj *model.NodeConnection) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -4743,7 +5719,7 @@ func (ec *executionContext) _NodeData_id(ctx context.Context, field graphql.Coll
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "NodeData",
+		Object:     "NodeConnection",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -4753,7 +5729,7 @@ func (ec *executionContext) _NodeData_id(ctx context.Context, field graphql.Coll
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Edges, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4765,12 +5741,12 @@ func (ec *executionContext) _NodeData_id(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*model.NodeConnectionEdge)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNNodeConnectionEdge2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeConnectionEdgeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _NodeData_name(ctx context.Context, field graphql.CollectedField, obj *model.NodeData) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *model.NodeConnection) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -4778,7 +5754,7 @@ func (ec *executionContext) _NodeData_name(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "NodeData",
+		Object:     "NodeConnection",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -4788,7 +5764,7 @@ func (ec *executionContext) _NodeData_name(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.PageInfo, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4800,12 +5776,12 @@ func (ec *executionContext) _NodeData_name(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*model.PageInfo)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNPageInfo2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐPageInfo(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _NodeData_value(ctx context.Context, field graphql.CollectedField, obj *model.NodeData) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *model.NodeConnection) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -4813,7 +5789,7 @@ func (ec *executionContext) _NodeData_value(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "NodeData",
+		Object:     "NodeConnection",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -4823,7 +5799,7 @@ func (ec *executionContext) _NodeData_value(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Value, nil
+		return obj.TotalCount, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4835,12 +5811,12 @@ func (ec *executionContext) _NodeData_value(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_game(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeConnectionEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *model.NodeConnectionEdge) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -4848,25 +5824,18 @@ func (ec *executionContext) _Query_game(ctx context.Context, field graphql.Colle
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "NodeConnectionEdge",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_game_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Game(rctx, args["id"].(string))
-	})
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Cursor, nil
+	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -4877,12 +5846,12 @@ func (ec *executionContext) _Query_game(ctx context.Context, field graphql.Colle
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Game)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNGame2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐGame(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_games(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeConnectionEdge_node(ctx context.Context, field graphql.CollectedField, obj *model.NodeConnectionEdge) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -4890,17 +5859,17 @@ func (ec *executionContext) _Query_games(ctx context.Context, field graphql.Coll
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "NodeConnectionEdge",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Games(rctx)
+		return obj.Node, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4912,12 +5881,12 @@ func (ec *executionContext) _Query_games(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Game)
+	res := resTmp.(*model.Node)
 	fc.Result = res
-	return ec.marshalNGame2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐGameᚄ(ctx, field.Selections, res)
+	return ec.marshalNNode2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNode(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeData_id(ctx context.Context, field graphql.CollectedField, obj *model.NodeData) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -4925,38 +5894,34 @@ func (ec *executionContext) _Query___type(ctx context.Context, field graphql.Col
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "NodeData",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query___type_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectType(args["name"].(string))
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeData_name(ctx context.Context, field graphql.CollectedField, obj *model.NodeData) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -4964,31 +5929,34 @@ func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "NodeData",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectSchema()
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Schema)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Router_id(ctx context.Context, field graphql.CollectedField, obj *model.Router) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeData_value(ctx context.Context, field graphql.CollectedField, obj *model.NodeData) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -4996,7 +5964,7 @@ func (ec *executionContext) _Router_id(ctx context.Context, field graphql.Collec
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Router",
+		Object:     "NodeData",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -5006,7 +5974,7 @@ func (ec *executionContext) _Router_id(ctx context.Context, field graphql.Collec
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Value, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5020,10 +5988,10 @@ func (ec *executionContext) _Router_id(ctx context.Context, field graphql.Collec
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Router_sessions(ctx context.Context, field graphql.CollectedField, obj *model.Router) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeData_kind(ctx context.Context, field graphql.CollectedField, obj *model.NodeData) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -5031,7 +5999,7 @@ func (ec *executionContext) _Router_sessions(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Router",
+		Object:     "NodeData",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   true,
@@ -5039,33 +6007,23 @@ func (ec *executionContext) _Router_sessions(ctx context.Context, field graphql.
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Router_sessions_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Router().Sessions(rctx, obj, args["owner"].(*string))
+		return ec.resolvers.NodeData().Kind(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Session)
+	res := resTmp.(*model.AttributeKind)
 	fc.Result = res
-	return ec.marshalNSession2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐSessionᚄ(ctx, field.Selections, res)
+	return ec.marshalOAttributeKind2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐAttributeKind(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Router_session(ctx context.Context, field graphql.CollectedField, obj *model.Router) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeData_int(ctx context.Context, field graphql.CollectedField, obj *model.NodeData) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -5073,7 +6031,7 @@ func (ec *executionContext) _Router_session(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Router",
+		Object:     "NodeData",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   true,
@@ -5081,16 +6039,9 @@ func (ec *executionContext) _Router_session(ctx context.Context, field graphql.C
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Router_session_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Router().Session(rctx, obj, args["id"].(string))
+		return ec.resolvers.NodeData().Int(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5099,12 +6050,12 @@ func (ec *executionContext) _Router_session(ctx context.Context, field graphql.C
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.Session)
+	res := resTmp.(*big.Int)
 	fc.Result = res
-	return ec.marshalOSession2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐSession(ctx, field.Selections, res)
+	return ec.marshalOBigInt2ᚖmathᚋbigᚐInt(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Router_transactions(ctx context.Context, field graphql.CollectedField, obj *model.Router) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeData_uint(ctx context.Context, field graphql.CollectedField, obj *model.NodeData) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -5112,7 +6063,7 @@ func (ec *executionContext) _Router_transactions(ctx context.Context, field grap
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Router",
+		Object:     "NodeData",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   true,
@@ -5120,33 +6071,23 @@ func (ec *executionContext) _Router_transactions(ctx context.Context, field grap
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Router_transactions_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Router().Transactions(rctx, obj, args["owner"].(*string), args["status"].([]model.ActionTransactionStatus))
+		return ec.resolvers.NodeData().Uint(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.ActionTransaction)
+	res := resTmp.(*big.Int)
 	fc.Result = res
-	return ec.marshalNActionTransaction2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐActionTransactionᚄ(ctx, field.Selections, res)
+	return ec.marshalOBigInt2ᚖmathᚋbigᚐInt(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Router_transaction(ctx context.Context, field graphql.CollectedField, obj *model.Router) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeData_bool(ctx context.Context, field graphql.CollectedField, obj *model.NodeData) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -5154,7 +6095,7 @@ func (ec *executionContext) _Router_transaction(ctx context.Context, field graph
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Router",
+		Object:     "NodeData",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   true,
@@ -5162,16 +6103,9 @@ func (ec *executionContext) _Router_transaction(ctx context.Context, field graph
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Router_transaction_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Router().Transaction(rctx, obj, args["id"].(string))
+		return ec.resolvers.NodeData().Bool(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5180,12 +6114,12 @@ func (ec *executionContext) _Router_transaction(ctx context.Context, field graph
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.ActionTransaction)
+	res := resTmp.(*bool)
 	fc.Result = res
-	return ec.marshalOActionTransaction2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐActionTransaction(ctx, field.Selections, res)
+	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Session_id(ctx context.Context, field graphql.CollectedField, obj *model.Session) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeData_address(ctx context.Context, field graphql.CollectedField, obj *model.NodeData) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -5193,34 +6127,31 @@ func (ec *executionContext) _Session_id(ctx context.Context, field graphql.Colle
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Session",
+		Object:     "NodeData",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return ec.resolvers.NodeData().Address(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Session_owner(ctx context.Context, field graphql.CollectedField, obj *model.Session) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeData_string(ctx context.Context, field graphql.CollectedField, obj *model.NodeData) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -5228,34 +6159,31 @@ func (ec *executionContext) _Session_owner(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Session",
+		Object:     "NodeData",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Owner, nil
+		return ec.resolvers.NodeData().String(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Session_scope(ctx context.Context, field graphql.CollectedField, obj *model.Session) (ret graphql.Marshaler) {
+func (ec *executionContext) _NodeData_bytes(ctx context.Context, field graphql.CollectedField, obj *model.NodeData) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -5263,34 +6191,31 @@ func (ec *executionContext) _Session_scope(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Session",
+		Object:     "NodeData",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Scope, nil
+		return ec.resolvers.NodeData().Bytes(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.SessionScope)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNSessionScope2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐSessionScope(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Session_expires(ctx context.Context, field graphql.CollectedField, obj *model.Session) (ret graphql.Marshaler) {
+func (ec *executionContext) _PageInfo_hasNextPage(ctx context.Context, field graphql.CollectedField, obj *model.PageInfo) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -5298,7 +6223,7 @@ func (ec *executionContext) _Session_expires(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Session",
+		Object:     "PageInfo",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -5308,7 +6233,7 @@ func (ec *executionContext) _Session_expires(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Expires, nil
+		return obj.HasNextPage, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5320,12 +6245,12 @@ func (ec *executionContext) _Session_expires(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _SessionScope_FullAccess(ctx context.Context, field graphql.CollectedField, obj *model.SessionScope) (ret graphql.Marshaler) {
+func (ec *executionContext) _PageInfo_hasPreviousPage(ctx context.Context, field graphql.CollectedField, obj *model.PageInfo) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -5333,7 +6258,7 @@ func (ec *executionContext) _SessionScope_FullAccess(ctx context.Context, field
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "SessionScope",
+		Object:     "PageInfo",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -5343,7 +6268,7 @@ func (ec *executionContext) _SessionScope_FullAccess(ctx context.Context, field
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.FullAccess, nil
+		return obj.HasPreviousPage, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5360,7 +6285,7 @@ func (ec *executionContext) _SessionScope_FullAccess(ctx context.Context, field
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _State_id(ctx context.Context, field graphql.CollectedField, obj *model.State) (ret graphql.Marshaler) {
+func (ec *executionContext) _PageInfo_startCursor(ctx context.Context, field graphql.CollectedField, obj *model.PageInfo) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -5368,7 +6293,7 @@ func (ec *executionContext) _State_id(ctx context.Context, field graphql.Collect
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "State",
+		Object:     "PageInfo",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -5378,24 +6303,21 @@ func (ec *executionContext) _State_id(ctx context.Context, field graphql.Collect
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.StartCursor, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _State_block(ctx context.Context, field graphql.CollectedField, obj *model.State) (ret graphql.Marshaler) {
+func (ec *executionContext) _PageInfo_endCursor(ctx context.Context, field graphql.CollectedField, obj *model.PageInfo) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -5403,34 +6325,31 @@ func (ec *executionContext) _State_block(ctx context.Context, field graphql.Coll
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "State",
+		Object:     "PageInfo",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.State().Block(rctx, obj)
+		return obj.EndCursor, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _State_simulated(ctx context.Context, field graphql.CollectedField, obj *model.State) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_game(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -5438,17 +6357,24 @@ func (ec *executionContext) _State_simulated(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "State",
+		Object:     "Query",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_game_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Simulated, nil
+		return ec.resolvers.Query().Game(rctx, args["id"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5460,12 +6386,12 @@ func (ec *executionContext) _State_simulated(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*model.Game)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNGame2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐGame(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _State_nodes(ctx context.Context, field graphql.CollectedField, obj *model.State) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_games(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -5473,7 +6399,7 @@ func (ec *executionContext) _State_nodes(ctx context.Context, field graphql.Coll
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "State",
+		Object:     "Query",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   true,
@@ -5481,16 +6407,9 @@ func (ec *executionContext) _State_nodes(ctx context.Context, field graphql.Coll
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_State_nodes_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.State().Nodes(rctx, obj, args["match"].(*model.Match))
+		return ec.resolvers.Query().Games(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5502,12 +6421,12 @@ func (ec *executionContext) _State_nodes(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Node)
+	res := resTmp.([]*model.Game)
 	fc.Result = res
-	return ec.marshalNNode2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeᚄ(ctx, field.Selections, res)
+	return ec.marshalNGame2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐGameᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _State_node(ctx context.Context, field graphql.CollectedField, obj *model.State) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_store(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -5515,7 +6434,7 @@ func (ec *executionContext) _State_node(ctx context.Context, field graphql.Colle
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "State",
+		Object:     "Query",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   true,
@@ -5524,7 +6443,7 @@ func (ec *executionContext) _State_node(ctx context.Context, field graphql.Colle
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_State_node_args(ctx, rawArgs)
+	args, err := ec.field_Query_store_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -5532,7 +6451,7 @@ func (ec *executionContext) _State_node(ctx context.Context, field graphql.Colle
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.State().Node(rctx, obj, args["match"].(*model.Match))
+		return ec.resolvers.Query().Store(rctx, args["name"].(string), args["key"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5541,16 +6460,901 @@ func (ec *executionContext) _State_node(ctx context.Context, field graphql.Colle
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.Node)
+	res := resTmp.(interface{})
 	fc.Result = res
-	return ec.marshalONode2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNode(ctx, field.Selections, res)
+	return ec.marshalOAny2interface(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Subscription_events(ctx context.Context, field graphql.CollectedField) (ret func() graphql.Marshaler) {
+func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
-			ret = nil
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query___type_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.introspectType(args["name"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Type)
+	fc.Result = res
+	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.introspectSchema()
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Schema)
+	fc.Result = res
+	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Router_id(ctx context.Context, field graphql.CollectedField, obj *model.Router) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Router",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Router_sessions(ctx context.Context, field graphql.CollectedField, obj *model.Router) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Router",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Router_sessions_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Router().Sessions(rctx, obj, args["owner"].(*string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.Session)
+	fc.Result = res
+	return ec.marshalNSession2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐSessionᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Router_session(ctx context.Context, field graphql.CollectedField, obj *model.Router) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Router",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Router_session_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Router().Session(rctx, obj, args["id"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.Session)
+	fc.Result = res
+	return ec.marshalOSession2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐSession(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Router_transactions(ctx context.Context, field graphql.CollectedField, obj *model.Router) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Router",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Router_transactions_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Router().Transactions(rctx, obj, args["owner"].(*string), args["status"].([]model.ActionTransactionStatus))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.ActionTransaction)
+	fc.Result = res
+	return ec.marshalNActionTransaction2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐActionTransactionᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Router_transaction(ctx context.Context, field graphql.CollectedField, obj *model.Router) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Router",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Router_transaction_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Router().Transaction(rctx, obj, args["id"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.ActionTransaction)
+	fc.Result = res
+	return ec.marshalOActionTransaction2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐActionTransaction(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Session_id(ctx context.Context, field graphql.CollectedField, obj *model.Session) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Session",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Session_owner(ctx context.Context, field graphql.CollectedField, obj *model.Session) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Session",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Owner, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Session_scope(ctx context.Context, field graphql.CollectedField, obj *model.Session) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Session",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Session().Scope(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.SessionScope)
+	fc.Result = res
+	return ec.marshalNSessionScope2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐSessionScope(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Session_expires(ctx context.Context, field graphql.CollectedField, obj *model.Session) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Session",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Expires, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Session_revoked(ctx context.Context, field graphql.CollectedField, obj *model.Session) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Session",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Revoked, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Session_status(ctx context.Context, field graphql.CollectedField, obj *model.Session) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Session",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Status, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(model.SessionStatus)
+	fc.Result = res
+	return ec.marshalNSessionStatus2githubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐSessionStatus(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _SessionScope_FullAccess(ctx context.Context, field graphql.CollectedField, obj *model.SessionScope) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "SessionScope",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.FullAccess, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _SessionScope_granted(ctx context.Context, field graphql.CollectedField, obj *model.SessionScope) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "SessionScope",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Granted, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _SessionScope_bits(ctx context.Context, field graphql.CollectedField, obj *model.SessionScope) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "SessionScope",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Bits, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _State_id(ctx context.Context, field graphql.CollectedField, obj *model.State) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "State",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _State_block(ctx context.Context, field graphql.CollectedField, obj *model.State) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "State",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.State().Block(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _State_simulated(ctx context.Context, field graphql.CollectedField, obj *model.State) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "State",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Simulated, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _State_finalized(ctx context.Context, field graphql.CollectedField, obj *model.State) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "State",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Finalized, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _State_nodes(ctx context.Context, field graphql.CollectedField, obj *model.State) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "State",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_State_nodes_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.State().Nodes(rctx, obj, args["match"].(*model.Match))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.Node)
+	fc.Result = res
+	return ec.marshalNNode2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _State_nodesConnection(ctx context.Context, field graphql.CollectedField, obj *model.State) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "State",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_State_nodesConnection_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.State().NodesConnection(rctx, obj, args["match"].(*model.Match), args["first"].(*int), args["after"].(*string), args["orderBy"].(*model.NodeOrder))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.NodeConnection)
+	fc.Result = res
+	return ec.marshalNNodeConnection2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeConnection(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _State_node(ctx context.Context, field graphql.CollectedField, obj *model.State) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "State",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_State_node_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.State().Node(rctx, obj, args["match"].(*model.Match))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.Node)
+	fc.Result = res
+	return ec.marshalONode2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNode(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _State_path(ctx context.Context, field graphql.CollectedField, obj *model.State) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "State",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_State_path_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.State().Path(rctx, obj, args["from"].(string), args["to"].(string), args["match"].(*model.Match), args["weighted"].(*bool))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*model.Edge)
+	fc.Result = res
+	return ec.marshalOEdge2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Subscription_events(ctx context.Context, field graphql.CollectedField) (ret func() graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = nil
 		}
 	}()
 	fc := &graphql.FieldContext{
@@ -6888,6 +8692,178 @@ func (ec *executionContext) ___Type_specifiedByURL(ctx context.Context, field gr
 
 // region    **************************** input.gotpl *****************************
 
+func (ec *executionContext) unmarshalInputAnnotationMatch(ctx context.Context, obj interface{}) (model.AnnotationMatch, error) {
+	var it model.AnnotationMatch
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	for k, v := range asMap {
+		switch k {
+		case "name":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+			it.Name, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "exists":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("exists"))
+			it.Exists, err = ec.unmarshalOBoolean2ᚖbool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "value":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("value"))
+			it.Value, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputBigIntMatch(ctx context.Context, obj interface{}) (model.BigIntMatch, error) {
+	var it model.BigIntMatch
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	for k, v := range asMap {
+		switch k {
+		case "eq":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("eq"))
+			it.Eq, err = ec.unmarshalOBigInt2ᚖmathᚋbigᚐInt(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "gt":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("gt"))
+			it.Gt, err = ec.unmarshalOBigInt2ᚖmathᚋbigᚐInt(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "gte":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("gte"))
+			it.Gte, err = ec.unmarshalOBigInt2ᚖmathᚋbigᚐInt(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "lt":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("lt"))
+			it.Lt, err = ec.unmarshalOBigInt2ᚖmathᚋbigᚐInt(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "lte":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("lte"))
+			it.Lte, err = ec.unmarshalOBigInt2ᚖmathᚋbigᚐInt(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputDataMatch(ctx context.Context, obj interface{}) (model.DataMatch, error) {
+	var it model.DataMatch
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	for k, v := range asMap {
+		switch k {
+		case "name":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+			it.Name, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "exists":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("exists"))
+			it.Exists, err = ec.unmarshalOBoolean2ᚖbool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "value":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("value"))
+			it.Value, err = ec.unmarshalOBigIntMatch2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐBigIntMatch(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "signed":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("signed"))
+			it.Signed, err = ec.unmarshalOBoolean2ᚖbool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputEdgeOrder(ctx context.Context, obj interface{}) (model.EdgeOrder, error) {
+	var it model.EdgeOrder
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	for k, v := range asMap {
+		switch k {
+		case "field":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("field"))
+			it.Field, err = ec.unmarshalNEdgeOrderField2githubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeOrderField(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "direction":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("direction"))
+			it.Direction, err = ec.unmarshalOOrderDirection2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐOrderDirection(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputMatch(ctx context.Context, obj interface{}) (model.Match, error) {
 	var it model.Match
 	asMap := map[string]interface{}{}
@@ -6929,6 +8905,30 @@ func (ec *executionContext) unmarshalInputMatch(ctx context.Context, obj interfa
 			if err != nil {
 				return it, err
 			}
+		case "data":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("data"))
+			it.Data, err = ec.unmarshalODataMatch2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐDataMatchᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "annotations":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("annotations"))
+			it.Annotations, err = ec.unmarshalOAnnotationMatch2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐAnnotationMatchᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "weight":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("weight"))
+			it.Weight, err = ec.unmarshalOBigIntMatch2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐBigIntMatch(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		case "limit":
 			var err error
 
@@ -6951,6 +8951,37 @@ func (ec *executionContext) unmarshalInputMatch(ctx context.Context, obj interfa
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputNodeOrder(ctx context.Context, obj interface{}) (model.NodeOrder, error) {
+	var it model.NodeOrder
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	for k, v := range asMap {
+		switch k {
+		case "field":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("field"))
+			it.Field, err = ec.unmarshalNNodeOrderField2githubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeOrderField(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "direction":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("direction"))
+			it.Direction, err = ec.unmarshalOOrderDirection2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐOrderDirection(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputRelMatch(ctx context.Context, obj interface{}) (model.RelMatch, error) {
 	var it model.RelMatch
 	asMap := map[string]interface{}{}
@@ -7329,6 +9360,16 @@ func (ec *executionContext) _BlockEvent(ctx context.Context, sel ast.SelectionSe
 
 			out.Values[i] = innerFunc(ctx)
 
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "rollback":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._BlockEvent_rollback(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
@@ -7622,14 +9663,85 @@ func (ec *executionContext) _Edge(ctx context.Context, sel ast.SelectionSet, obj
 				return ec._Edge_src(ctx, field, obj)
 			}
 
-			out.Values[i] = innerFunc(ctx)
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "dst":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Edge_dst(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "weight":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Edge_weight(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "key":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Edge_key(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "rel":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Edge_rel(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "dir":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Edge_dir(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var edgeConnectionImplementors = []string{"EdgeConnection"}
 
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
-		case "dst":
+func (ec *executionContext) _EdgeConnection(ctx context.Context, sel ast.SelectionSet, obj *model.EdgeConnection) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, edgeConnectionImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("EdgeConnection")
+		case "edges":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Edge_dst(ctx, field, obj)
+				return ec._EdgeConnection_edges(ctx, field, obj)
 			}
 
 			out.Values[i] = innerFunc(ctx)
@@ -7637,9 +9749,9 @@ func (ec *executionContext) _Edge(ctx context.Context, sel ast.SelectionSet, obj
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "weight":
+		case "pageInfo":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Edge_weight(ctx, field, obj)
+				return ec._EdgeConnection_pageInfo(ctx, field, obj)
 			}
 
 			out.Values[i] = innerFunc(ctx)
@@ -7647,9 +9759,9 @@ func (ec *executionContext) _Edge(ctx context.Context, sel ast.SelectionSet, obj
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "key":
+		case "totalCount":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Edge_key(ctx, field, obj)
+				return ec._EdgeConnection_totalCount(ctx, field, obj)
 			}
 
 			out.Values[i] = innerFunc(ctx)
@@ -7657,9 +9769,30 @@ func (ec *executionContext) _Edge(ctx context.Context, sel ast.SelectionSet, obj
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "rel":
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var edgeConnectionEdgeImplementors = []string{"EdgeConnectionEdge"}
+
+func (ec *executionContext) _EdgeConnectionEdge(ctx context.Context, sel ast.SelectionSet, obj *model.EdgeConnectionEdge) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, edgeConnectionEdgeImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("EdgeConnectionEdge")
+		case "cursor":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Edge_rel(ctx, field, obj)
+				return ec._EdgeConnectionEdge_cursor(ctx, field, obj)
 			}
 
 			out.Values[i] = innerFunc(ctx)
@@ -7667,9 +9800,9 @@ func (ec *executionContext) _Edge(ctx context.Context, sel ast.SelectionSet, obj
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "dir":
+		case "node":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Edge_dir(ctx, field, obj)
+				return ec._EdgeConnectionEdge_node(ctx, field, obj)
 			}
 
 			out.Values[i] = innerFunc(ctx)
@@ -7972,45 +10105,317 @@ func (ec *executionContext) _Node(ctx context.Context, sel ast.SelectionSet, obj
 				return ec._Node_edges(ctx, field, obj)
 			}
 
-			out.Values[i] = innerFunc(ctx)
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "edgesConnection":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Node_edgesConnection(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "edge":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Node_edge(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+		case "value":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Node_value(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+		case "sum":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Node_sum(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "count":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Node_count(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var nodeConnectionImplementors = []string{"NodeConnection"}
+
+func (ec *executionContext) _NodeConnection(ctx context.Context, sel ast.SelectionSet, obj *model.NodeConnection) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, nodeConnectionImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("NodeConnection")
+		case "edges":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._NodeConnection_edges(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "pageInfo":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._NodeConnection_pageInfo(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "totalCount":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._NodeConnection_totalCount(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var nodeConnectionEdgeImplementors = []string{"NodeConnectionEdge"}
+
+func (ec *executionContext) _NodeConnectionEdge(ctx context.Context, sel ast.SelectionSet, obj *model.NodeConnectionEdge) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, nodeConnectionEdgeImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("NodeConnectionEdge")
+		case "cursor":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._NodeConnectionEdge_cursor(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "node":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._NodeConnectionEdge_node(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var nodeDataImplementors = []string{"NodeData"}
+
+func (ec *executionContext) _NodeData(ctx context.Context, sel ast.SelectionSet, obj *model.NodeData) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, nodeDataImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("NodeData")
+		case "id":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._NodeData_id(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "name":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._NodeData_name(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "value":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._NodeData_value(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "kind":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._NodeData_kind(ctx, field, obj)
+				return res
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "int":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._NodeData_int(ctx, field, obj)
+				return res
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "uint":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._NodeData_uint(ctx, field, obj)
+				return res
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "bool":
+			field := field
 
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
-		case "edge":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Node_edge(ctx, field, obj)
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._NodeData_bool(ctx, field, obj)
+				return res
 			}
 
-			out.Values[i] = innerFunc(ctx)
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "address":
+			field := field
 
-		case "value":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Node_value(ctx, field, obj)
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._NodeData_address(ctx, field, obj)
+				return res
 			}
 
-			out.Values[i] = innerFunc(ctx)
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "string":
+			field := field
 
-		case "sum":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Node_sum(ctx, field, obj)
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._NodeData_string(ctx, field, obj)
+				return res
 			}
 
-			out.Values[i] = innerFunc(ctx)
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "bytes":
+			field := field
 
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
-		case "count":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Node_count(ctx, field, obj)
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._NodeData_bytes(ctx, field, obj)
+				return res
 			}
 
-			out.Values[i] = innerFunc(ctx)
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
 
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
+			})
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -8022,19 +10427,19 @@ func (ec *executionContext) _Node(ctx context.Context, sel ast.SelectionSet, obj
 	return out
 }
 
-var nodeDataImplementors = []string{"NodeData"}
+var pageInfoImplementors = []string{"PageInfo"}
 
-func (ec *executionContext) _NodeData(ctx context.Context, sel ast.SelectionSet, obj *model.NodeData) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, nodeDataImplementors)
+func (ec *executionContext) _PageInfo(ctx context.Context, sel ast.SelectionSet, obj *model.PageInfo) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, pageInfoImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("NodeData")
-		case "id":
+			out.Values[i] = graphql.MarshalString("PageInfo")
+		case "hasNextPage":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._NodeData_id(ctx, field, obj)
+				return ec._PageInfo_hasNextPage(ctx, field, obj)
 			}
 
 			out.Values[i] = innerFunc(ctx)
@@ -8042,9 +10447,9 @@ func (ec *executionContext) _NodeData(ctx context.Context, sel ast.SelectionSet,
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "name":
+		case "hasPreviousPage":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._NodeData_name(ctx, field, obj)
+				return ec._PageInfo_hasPreviousPage(ctx, field, obj)
 			}
 
 			out.Values[i] = innerFunc(ctx)
@@ -8052,16 +10457,20 @@ func (ec *executionContext) _NodeData(ctx context.Context, sel ast.SelectionSet,
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "value":
+		case "startCursor":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._NodeData_value(ctx, field, obj)
+				return ec._PageInfo_startCursor(ctx, field, obj)
 			}
 
 			out.Values[i] = innerFunc(ctx)
 
-			if out.Values[i] == graphql.Null {
-				invalids++
+		case "endCursor":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._PageInfo_endCursor(ctx, field, obj)
 			}
+
+			out.Values[i] = innerFunc(ctx)
+
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -8135,6 +10544,26 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
 			}
 
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "store":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_store(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
@@ -8286,7 +10715,7 @@ func (ec *executionContext) _Session(ctx context.Context, sel ast.SelectionSet,
 			out.Values[i] = innerFunc(ctx)
 
 			if out.Values[i] == graphql.Null {
-				invalids++
+				atomic.AddUint32(&invalids, 1)
 			}
 		case "owner":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -8296,27 +10725,57 @@ func (ec *executionContext) _Session(ctx context.Context, sel ast.SelectionSet,
 			out.Values[i] = innerFunc(ctx)
 
 			if out.Values[i] == graphql.Null {
-				invalids++
+				atomic.AddUint32(&invalids, 1)
 			}
 		case "scope":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Session_scope(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "expires":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Session_scope(ctx, field, obj)
+				return ec._Session_expires(ctx, field, obj)
 			}
 
 			out.Values[i] = innerFunc(ctx)
 
 			if out.Values[i] == graphql.Null {
-				invalids++
+				atomic.AddUint32(&invalids, 1)
 			}
-		case "expires":
+		case "revoked":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Session_expires(ctx, field, obj)
+				return ec._Session_revoked(ctx, field, obj)
 			}
 
 			out.Values[i] = innerFunc(ctx)
 
 			if out.Values[i] == graphql.Null {
-				invalids++
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "status":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Session_status(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
 			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
@@ -8346,6 +10805,26 @@ func (ec *executionContext) _SessionScope(ctx context.Context, sel ast.Selection
 
 			out.Values[i] = innerFunc(ctx)
 
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "granted":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SessionScope_granted(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "bits":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SessionScope_bits(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
@@ -8407,6 +10886,16 @@ func (ec *executionContext) _State(ctx context.Context, sel ast.SelectionSet, ob
 
 			out.Values[i] = innerFunc(ctx)
 
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "finalized":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._State_finalized(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
 			if out.Values[i] == graphql.Null {
 				atomic.AddUint32(&invalids, 1)
 			}
@@ -8426,6 +10915,26 @@ func (ec *executionContext) _State(ctx context.Context, sel ast.SelectionSet, ob
 				return res
 			}
 
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "nodesConnection":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._State_nodesConnection(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
 			out.Concurrently(i, func() graphql.Marshaler {
 				return innerFunc(ctx)
 
@@ -8443,6 +10952,23 @@ func (ec *executionContext) _State(ctx context.Context, sel ast.SelectionSet, ob
 				return res
 			}
 
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "path":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._State_path(ctx, field, obj)
+				return res
+			}
+
 			out.Concurrently(i, func() graphql.Marshaler {
 				return innerFunc(ctx)
 
@@ -9021,6 +11547,11 @@ func (ec *executionContext) marshalNAnnotation2ᚕᚖgithubᚗcomᚋplaymintᚋd
 	return ret
 }
 
+func (ec *executionContext) unmarshalNAnnotationMatch2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐAnnotationMatch(ctx context.Context, v interface{}) (*model.AnnotationMatch, error) {
+	res, err := ec.unmarshalInputAnnotationMatch(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) unmarshalNBigInt2ᚕᚖmathᚋbigᚐIntᚄ(ctx context.Context, v interface{}) ([]*big.Int, error) {
 	var vSlice []interface{}
 	if v != nil {
@@ -9086,20 +11617,79 @@ func (ec *executionContext) marshalNBoolean2bool(ctx context.Context, sel ast.Se
 			ec.Errorf(ctx, "must not be null")
 		}
 	}
-	return res
+	return res
+}
+
+func (ec *executionContext) unmarshalNDataMatch2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐDataMatch(ctx context.Context, v interface{}) (*model.DataMatch, error) {
+	res, err := ec.unmarshalInputDataMatch(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNDispatcher2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐDispatcher(ctx context.Context, sel ast.SelectionSet, v *model.Dispatcher) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._Dispatcher(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNERC721Attribute2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐERC721Attributeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.ERC721Attribute) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNERC721Attribute2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐERC721Attribute(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
 }
 
-func (ec *executionContext) marshalNDispatcher2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐDispatcher(ctx context.Context, sel ast.SelectionSet, v *model.Dispatcher) graphql.Marshaler {
+func (ec *executionContext) marshalNERC721Attribute2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐERC721Attribute(ctx context.Context, sel ast.SelectionSet, v *model.ERC721Attribute) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "must not be null")
 		}
 		return graphql.Null
 	}
-	return ec._Dispatcher(ctx, sel, v)
+	return ec._ERC721Attribute(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNERC721Attribute2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐERC721Attributeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.ERC721Attribute) graphql.Marshaler {
+func (ec *executionContext) marshalNEdge2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Edge) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -9123,7 +11713,7 @@ func (ec *executionContext) marshalNERC721Attribute2ᚕᚖgithubᚗcomᚋplaymin
 			if !isLen1 {
 				defer wg.Done()
 			}
-			ret[i] = ec.marshalNERC721Attribute2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐERC721Attribute(ctx, sel, v[i])
+			ret[i] = ec.marshalNEdge2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdge(ctx, sel, v[i])
 		}
 		if isLen1 {
 			f(i)
@@ -9143,17 +11733,27 @@ func (ec *executionContext) marshalNERC721Attribute2ᚕᚖgithubᚗcomᚋplaymin
 	return ret
 }
 
-func (ec *executionContext) marshalNERC721Attribute2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐERC721Attribute(ctx context.Context, sel ast.SelectionSet, v *model.ERC721Attribute) graphql.Marshaler {
+func (ec *executionContext) marshalNEdge2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdge(ctx context.Context, sel ast.SelectionSet, v *model.Edge) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "must not be null")
 		}
 		return graphql.Null
 	}
-	return ec._ERC721Attribute(ctx, sel, v)
+	return ec._Edge(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNEdge2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Edge) graphql.Marshaler {
+func (ec *executionContext) marshalNEdgeConnection2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeConnection(ctx context.Context, sel ast.SelectionSet, v *model.EdgeConnection) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._EdgeConnection(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNEdgeConnectionEdge2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeConnectionEdgeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.EdgeConnectionEdge) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -9177,7 +11777,7 @@ func (ec *executionContext) marshalNEdge2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑno
 			if !isLen1 {
 				defer wg.Done()
 			}
-			ret[i] = ec.marshalNEdge2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdge(ctx, sel, v[i])
+			ret[i] = ec.marshalNEdgeConnectionEdge2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeConnectionEdge(ctx, sel, v[i])
 		}
 		if isLen1 {
 			f(i)
@@ -9197,14 +11797,24 @@ func (ec *executionContext) marshalNEdge2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑno
 	return ret
 }
 
-func (ec *executionContext) marshalNEdge2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdge(ctx context.Context, sel ast.SelectionSet, v *model.Edge) graphql.Marshaler {
+func (ec *executionContext) marshalNEdgeConnectionEdge2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeConnectionEdge(ctx context.Context, sel ast.SelectionSet, v *model.EdgeConnectionEdge) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "must not be null")
 		}
 		return graphql.Null
 	}
-	return ec._Edge(ctx, sel, v)
+	return ec._EdgeConnectionEdge(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNEdgeOrderField2githubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeOrderField(ctx context.Context, v interface{}) (model.EdgeOrderField, error) {
+	var res model.EdgeOrderField
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNEdgeOrderField2githubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeOrderField(ctx context.Context, sel ast.SelectionSet, v model.EdgeOrderField) graphql.Marshaler {
+	return v
 }
 
 func (ec *executionContext) marshalNEvent2githubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEvent(ctx context.Context, sel ast.SelectionSet, v model.Event) graphql.Marshaler {
@@ -9359,6 +11969,74 @@ func (ec *executionContext) marshalNNode2ᚖgithubᚗcomᚋplaymintᚋdsᚑnode
 	return ec._Node(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNNodeConnection2githubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeConnection(ctx context.Context, sel ast.SelectionSet, v model.NodeConnection) graphql.Marshaler {
+	return ec._NodeConnection(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNNodeConnection2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeConnection(ctx context.Context, sel ast.SelectionSet, v *model.NodeConnection) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._NodeConnection(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNNodeConnectionEdge2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeConnectionEdgeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.NodeConnectionEdge) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNNodeConnectionEdge2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeConnectionEdge(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNNodeConnectionEdge2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeConnectionEdge(ctx context.Context, sel ast.SelectionSet, v *model.NodeConnectionEdge) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._NodeConnectionEdge(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNNodeData2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeData(ctx context.Context, sel ast.SelectionSet, v []*model.NodeData) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
@@ -9397,6 +12075,26 @@ func (ec *executionContext) marshalNNodeData2ᚕᚖgithubᚗcomᚋplaymintᚋds
 	return ret
 }
 
+func (ec *executionContext) unmarshalNNodeOrderField2githubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeOrderField(ctx context.Context, v interface{}) (model.NodeOrderField, error) {
+	var res model.NodeOrderField
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNNodeOrderField2githubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeOrderField(ctx context.Context, sel ast.SelectionSet, v model.NodeOrderField) graphql.Marshaler {
+	return v
+}
+
+func (ec *executionContext) marshalNPageInfo2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐPageInfo(ctx context.Context, sel ast.SelectionSet, v *model.PageInfo) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._PageInfo(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNRelMatch2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐRelMatch(ctx context.Context, v interface{}) (*model.RelMatch, error) {
 	res, err := ec.unmarshalInputRelMatch(ctx, v)
 	return &res, graphql.ErrorOnPath(ctx, err)
@@ -9480,6 +12178,10 @@ func (ec *executionContext) marshalNSession2ᚖgithubᚗcomᚋplaymintᚋdsᚑno
 	return ec._Session(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNSessionScope2githubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐSessionScope(ctx context.Context, sel ast.SelectionSet, v model.SessionScope) graphql.Marshaler {
+	return ec._SessionScope(ctx, sel, &v)
+}
+
 func (ec *executionContext) marshalNSessionScope2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐSessionScope(ctx context.Context, sel ast.SelectionSet, v *model.SessionScope) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
@@ -9490,6 +12192,16 @@ func (ec *executionContext) marshalNSessionScope2ᚖgithubᚗcomᚋplaymintᚋds
 	return ec._SessionScope(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalNSessionStatus2githubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐSessionStatus(ctx context.Context, v interface{}) (model.SessionStatus, error) {
+	var res model.SessionStatus
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNSessionStatus2githubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐSessionStatus(ctx context.Context, sel ast.SelectionSet, v model.SessionStatus) graphql.Marshaler {
+	return v
+}
+
 func (ec *executionContext) marshalNState2githubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐState(ctx context.Context, sel ast.SelectionSet, v model.State) graphql.Marshaler {
 	return ec._State(ctx, sel, &v)
 }
@@ -9885,6 +12597,58 @@ func (ec *executionContext) marshalOAnnotation2ᚖgithubᚗcomᚋplaymintᚋds
 	return ec._Annotation(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalOAnnotationMatch2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐAnnotationMatchᚄ(ctx context.Context, v interface{}) ([]*model.AnnotationMatch, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []interface{}
+	if v != nil {
+		vSlice = graphql.CoerceList(v)
+	}
+	var err error
+	res := make([]*model.AnnotationMatch, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNAnnotationMatch2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐAnnotationMatch(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) unmarshalOAny2interface(ctx context.Context, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := graphql.UnmarshalAny(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOAny2interface(ctx context.Context, sel ast.SelectionSet, v interface{}) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	res := graphql.MarshalAny(v)
+	return res
+}
+
+func (ec *executionContext) unmarshalOAttributeKind2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐAttributeKind(ctx context.Context, v interface{}) (*model.AttributeKind, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(model.AttributeKind)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOAttributeKind2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐAttributeKind(ctx context.Context, sel ast.SelectionSet, v *model.AttributeKind) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) unmarshalOBigInt2ᚖmathᚋbigᚐInt(ctx context.Context, v interface{}) (*big.Int, error) {
 	if v == nil {
 		return nil, nil
@@ -9901,6 +12665,14 @@ func (ec *executionContext) marshalOBigInt2ᚖmathᚋbigᚐInt(ctx context.Conte
 	return res
 }
 
+func (ec *executionContext) unmarshalOBigIntMatch2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐBigIntMatch(ctx context.Context, v interface{}) (*model.BigIntMatch, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputBigIntMatch(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) unmarshalOBoolean2bool(ctx context.Context, v interface{}) (bool, error) {
 	res, err := graphql.UnmarshalBoolean(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -9927,6 +12699,73 @@ func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast
 	return res
 }
 
+func (ec *executionContext) unmarshalODataMatch2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐDataMatchᚄ(ctx context.Context, v interface{}) ([]*model.DataMatch, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []interface{}
+	if v != nil {
+		vSlice = graphql.CoerceList(v)
+	}
+	var err error
+	res := make([]*model.DataMatch, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNDataMatch2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐDataMatch(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOEdge2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Edge) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNEdge2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdge(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) marshalOEdge2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdge(ctx context.Context, sel ast.SelectionSet, v *model.Edge) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -9934,6 +12773,14 @@ func (ec *executionContext) marshalOEdge2ᚖgithubᚗcomᚋplaymintᚋdsᚑnode
 	return ec._Edge(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalOEdgeOrder2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐEdgeOrder(ctx context.Context, v interface{}) (*model.EdgeOrder, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputEdgeOrder(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) unmarshalOInt2ᚖint(ctx context.Context, v interface{}) (*int, error) {
 	if v == nil {
 		return nil, nil
@@ -9972,6 +12819,30 @@ func (ec *executionContext) marshalONodeData2ᚖgithubᚗcomᚋplaymintᚋdsᚑn
 	return ec._NodeData(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalONodeOrder2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐNodeOrder(ctx context.Context, v interface{}) (*model.NodeOrder, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputNodeOrder(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) unmarshalOOrderDirection2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐOrderDirection(ctx context.Context, v interface{}) (*model.OrderDirection, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(model.OrderDirection)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOOrderDirection2ᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐOrderDirection(ctx context.Context, sel ast.SelectionSet, v *model.OrderDirection) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) unmarshalORelMatch2ᚕᚖgithubᚗcomᚋplaymintᚋdsᚑnodeᚋpkgᚋapiᚋmodelᚐRelMatchᚄ(ctx context.Context, v interface{}) ([]*model.RelMatch, error) {
 	if v == nil {
 		return nil, nil
@@ -6,6 +6,7 @@ import (
 	"io"
 	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -15,22 +16,49 @@ type BigInt *big.Int
 
 func MarshalBigInt(bignum *big.Int) graphql.Marshaler {
 	return graphql.WriterFunc(func(w io.Writer) {
-		if bignum.Sign() == 0 {
-			_, _ = w.Write([]byte(`"0x0"`))
-		} else {
-			_, _ = w.Write([]byte(strconv.Quote(hexutil.Encode(bignum.Bytes()))))
-		}
+		_, _ = w.Write([]byte(strconv.Quote(encodeBigInt(bignum))))
 	})
 }
 
+// encodeBigInt encodes as hex bytes, negative numbers are prefixed with -
+func encodeBigInt(bignum *big.Int) string {
+	switch bignum.Sign() {
+	case 0:
+		return "0x0"
+	case -1:
+		return "-" + hexutil.Encode(bignum.Bytes())
+	default:
+		return hexutil.Encode(bignum.Bytes())
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	digits := strings.TrimPrefix(s, "-")
+	if !strings.HasPrefix(digits, "0x") && !strings.HasPrefix(digits, "0X") {
+		return nil, hexutil.ErrMissingPrefix
+	}
+	if len(digits) == 2 {
+		// empty bytes, as encoded by hexutil
+		return big.NewInt(0), nil
+	}
+	n, ok := big.NewInt(0).SetString(digits[2:], 16)
+	if !ok {
+		return nil, hexutil.ErrSyntax
+	}
+	if strings.HasPrefix(s, "-") {
+		n.Neg(n)
+	}
+	return n, nil
+}
+
 func UnmarshalBigInt(v interface{}) (*big.Int, error) {
 	switch v := v.(type) {
 	case string:
-		b, err := hexutil.Decode(v)
+		n, err := decodeBigInt(v)
 		if err != nil {
 			return nil, fmt.Errorf("%v failed to decode as BigInt", v)
 		}
-		return big.NewInt(0).SetBytes(b), nil
+		return n, nil
 	case []byte:
 		return big.NewInt(0).SetBytes(v), nil
 	case int:
@@ -50,7 +78,7 @@ func UnmarshalBigInt(v interface{}) (*big.Int, error) {
 
 func ClientMarshalBigInt(bignum *BigInt) ([]byte, error) {
 	n := (*big.Int)(*bignum)
-	return []byte(strconv.Quote(hexutil.Encode(n.Bytes()))), nil
+	return []byte(strconv.Quote(encodeBigInt(n))), nil
 }
 
 func ClientUnmarshalBigInt(b []byte, v *BigInt) error {
@@ -63,11 +91,10 @@ func ClientUnmarshalBigInt(b []byte, v *BigInt) error {
 		return nil
 	}
 
-	nBytes, err := hexutil.Decode(s)
+	n, err := decodeBigInt(s)
 	if err != nil {
 		return err
 	}
-	bn := BigInt(big.NewInt(0).SetBytes(nBytes))
-	*v = bn
+	*v = BigInt(n)
 	return nil
 }
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestBigIntEncoding(t *testing.T) {
+	large, _ := new(big.Int).SetString("1180591620717411303424", 10) // 2^70
+	tests := []struct {
+		name string
+		n    *big.Int
+		want string
+	}{
+		{name: "zero", n: big.NewInt(0), want: `"0x0"`},
+		{name: "positive", n: big.NewInt(10), want: `"0x0a"`},
+		{name: "negative", n: big.NewInt(-10), want: `"-0x0a"`},
+		{name: "large", n: large, want: `"0x400000000000000000"`},
+		{name: "large negative", n: new(big.Int).Neg(large), want: `"-0x400000000000000000"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			MarshalBigInt(tt.n).MarshalGQL(&buf)
+			if buf.String() != tt.want {
+				t.Fatalf("marshalled as %s, want %s", buf.String(), tt.want)
+			}
+			var v BigInt
+			if err := ClientUnmarshalBigInt(buf.Bytes(), &v); err != nil {
+				t.Fatal(err)
+			}
+			if got := (*big.Int)(v); got.Cmp(tt.n) != 0 {
+				t.Fatalf("unmarshalled as %v, want %v", got, tt.n)
+			}
+		})
+	}
+}
+
+func TestUnmarshalBigInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       interface{}
+		want    *big.Int
+		wantErr bool
+	}{
+		{name: "hex", v: "0x0a", want: big.NewInt(10)},
+		{name: "unpadded hex", v: "0xa", want: big.NewInt(10)},
+		{name: "negative hex", v: "-0x0a", want: big.NewInt(-10)},
+		{name: "empty hex", v: "0x", want: big.NewInt(0)},
+		{name: "int", v: 7, want: big.NewInt(7)},
+		{name: "bool", v: true, want: big.NewInt(1)},
+		{name: "missing prefix", v: "10", wantErr: true},
+		{name: "invalid digits", v: "0xzz", wantErr: true},
+		{name: "unsupported type", v: 1.5, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalBigInt(tt.v)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Cmp(tt.want) != 0 {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -20,7 +20,7 @@ func (game *Game) Dispatcher() *Dispatcher {
 	}
 }
 
-func (game *Game) State(requestedBlock *int, allowSimulated *bool) *State {
+func (game *Game) State(requestedBlock *int, allowSimulated *bool, onlyFinalized *bool) *State {
 
 	s := &State{
 		ID: game.StateAddress.Hex(),
@@ -28,6 +28,9 @@ func (game *Game) State(requestedBlock *int, allowSimulated *bool) *State {
 	if allowSimulated != nil && *allowSimulated {
 		s.Simulated = true
 	}
+	if onlyFinalized != nil && *onlyFinalized {
+		s.Finalized = true
+	}
 	if requestedBlock != nil {
 		s.Block = *requestedBlock
 	}
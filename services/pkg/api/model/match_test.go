@@ -0,0 +1,205 @@
+package model
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestGetNodesFilters(t *testing.T) {
+	p1 := testNodeID(1, 1)
+	p2 := testNodeID(1, 2)
+	p3 := testNodeID(1, 3)
+	t1 := testNodeID(2, 1)
+	t2 := testNodeID(2, 2)
+	t3 := testNodeID(2, 3)
+
+	g := testKind(testKind(NewGraph(0), 1, "Player"), 2, "Tile")
+	g, owns := testRel(g, 1, "Owns", WeightKindUint64)
+	g = g.SetData(p1, "hp", "0x05", 1)
+	g = g.SetData(p2, "hp", "0xff", 1)
+	g = g.SetData(p3, "xp", "0x01", 1)
+	g = g.SetAnnotationData(p1, "name", "ref1", "alice", 1)
+	g = g.SetEdge(owns, 0, p1, t1, nil, 1)
+	g = g.SetEdge(owns, 1, p1, t2, nil, 1)
+	g = g.SetEdge(owns, 0, p2, t3, nil, 1)
+
+	yes, no := true, false
+	alice, bob := "alice", "bob"
+	in := RelMatchDirectionIn
+	players := []string{"Player"}
+	tests := []struct {
+		name  string
+		match *Match
+		want  []string
+	}{
+		{
+			name:  "data exists",
+			match: &Match{Kinds: players, Data: []*DataMatch{{Name: "hp", Exists: &yes}}},
+			want:  sorted(p1, p2),
+		},
+		{
+			name:  "data missing",
+			match: &Match{Kinds: players, Data: []*DataMatch{{Name: "hp", Exists: &no}}},
+			want:  []string{p3},
+		},
+		{
+			name:  "data equal",
+			match: &Match{Kinds: players, Data: []*DataMatch{{Name: "hp", Value: &BigIntMatch{Eq: big.NewInt(5)}}}},
+			want:  []string{p1},
+		},
+		{
+			name:  "data unsigned",
+			match: &Match{Kinds: players, Data: []*DataMatch{{Name: "hp", Value: &BigIntMatch{Gt: big.NewInt(4)}}}},
+			want:  sorted(p1, p2),
+		},
+		{
+			name:  "data signed",
+			match: &Match{Kinds: players, Data: []*DataMatch{{Name: "hp", Signed: &yes, Value: &BigIntMatch{Lt: big.NewInt(0)}}}},
+			want:  []string{p2},
+		},
+		{
+			name:  "missing data compares as zero",
+			match: &Match{Kinds: players, Data: []*DataMatch{{Name: "hp", Value: &BigIntMatch{Lte: big.NewInt(0)}}}},
+			want:  []string{p3},
+		},
+		{
+			name:  "annotation exists",
+			match: &Match{Kinds: players, Annotations: []*AnnotationMatch{{Name: "name", Exists: &yes}}},
+			want:  []string{p1},
+		},
+		{
+			name:  "annotation value",
+			match: &Match{Kinds: players, Annotations: []*AnnotationMatch{{Name: "name", Value: &alice}}},
+			want:  []string{p1},
+		},
+		{
+			name:  "annotation other value",
+			match: &Match{Kinds: players, Annotations: []*AnnotationMatch{{Name: "name", Value: &bob}}},
+			want:  []string{},
+		},
+		{
+			name:  "has out",
+			match: &Match{Has: []*RelMatch{{Rel: "Owns"}}},
+			want:  sorted(p1, p2),
+		},
+		{
+			name:  "has in",
+			match: &Match{Has: []*RelMatch{{Rel: "Owns", Dir: &in}}},
+			want:  sorted(t1, t2, t3),
+		},
+		{
+			name: "every condition",
+			match: &Match{
+				Kinds:       players,
+				Has:         []*RelMatch{{Rel: "Owns"}},
+				Data:        []*DataMatch{{Name: "hp", Exists: &yes}},
+				Annotations: []*AnnotationMatch{{Name: "name", Exists: &no}},
+			},
+			want: []string{p2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sortedNodeIDs(g.GetNodes(tt.match))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEdgesFilters(t *testing.T) {
+	a := testNodeID(1, 1)
+	b := testNodeID(1, 2)
+	c := testNodeID(1, 3)
+	d := testNodeID(1, 4)
+
+	g, owns := testRel(NewGraph(0), 1, "Owns", WeightKindUint64)
+	g, near := testRel(g, 2, "Near", WeightKindInt64)
+	g = g.SetEdge(owns, 0, a, b, big.NewInt(10), 1)
+	g = g.SetEdge(owns, 1, a, c, big.NewInt(3), 1)
+	g = g.SetEdge(near, 0, b, d, twos(-2), 1)
+	g = g.SetData(c, "hp", "0x01", 1)
+
+	yes := true
+	one, two := 1, 2
+	tests := []struct {
+		name  string
+		match *Match
+		want  []string
+	}{
+		{
+			name:  "weight lower bound",
+			match: &Match{Via: []*RelMatch{{Rel: "Owns"}}, Weight: &BigIntMatch{Gte: big.NewInt(5)}},
+			want:  []string{"OUT " + b + " 0"},
+		},
+		{
+			name:  "weight range",
+			match: &Match{Via: []*RelMatch{{Rel: "Owns"}}, Weight: &BigIntMatch{Gt: big.NewInt(2), Lt: big.NewInt(10)}},
+			want:  []string{"OUT " + c + " 1"},
+		},
+		{
+			name:  "signed weight",
+			match: &Match{Weight: &BigIntMatch{Lt: big.NewInt(0)}, MaxDepth: &one},
+			want:  []string{"OUT " + d + " 0"},
+		},
+		{
+			name:  "data on the far node",
+			match: &Match{Data: []*DataMatch{{Name: "hp", Exists: &yes}}},
+			want:  []string{"OUT " + c + " 1"},
+		},
+		{
+			name:  "has still follows edges that do not match",
+			match: &Match{Has: []*RelMatch{{Rel: "Near"}}, MaxDepth: &one},
+			want:  []string{"OUT " + d + " 0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edges, err := g.get(a).Edges(tt.match)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := edgeDescriptions(edges); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// three edges are reachable within one hop, the limit stops at two
+	edges, err := g.get(a).Edges(&Match{Limit: &two, MaxDepth: &one})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(edges) != two {
+		t.Fatalf("got %d edges with limit %d", len(edges), two)
+	}
+}
+
+func TestBigIntMatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		match *BigIntMatch
+		n     int64
+		want  bool
+	}{
+		{name: "no bounds", match: &BigIntMatch{}, n: 3, want: true},
+		{name: "nil", match: nil, n: 3, want: true},
+		{name: "eq", match: &BigIntMatch{Eq: big.NewInt(3)}, n: 3, want: true},
+		{name: "not eq", match: &BigIntMatch{Eq: big.NewInt(3)}, n: 4, want: false},
+		{name: "gt boundary", match: &BigIntMatch{Gt: big.NewInt(3)}, n: 3, want: false},
+		{name: "gte boundary", match: &BigIntMatch{Gte: big.NewInt(3)}, n: 3, want: true},
+		{name: "lt boundary", match: &BigIntMatch{Lt: big.NewInt(3)}, n: 3, want: false},
+		{name: "lte boundary", match: &BigIntMatch{Lte: big.NewInt(3)}, n: 3, want: true},
+		{name: "inside range", match: &BigIntMatch{Gte: big.NewInt(-1), Lt: big.NewInt(1)}, n: -1, want: true},
+		{name: "outside range", match: &BigIntMatch{Gte: big.NewInt(-1), Lt: big.NewInt(1)}, n: 1, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.match.MatchBigInt(big.NewInt(tt.n)); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package model
 import (
 	"fmt"
 	"io"
+	"math/big"
 	"strconv"
 )
 
@@ -30,11 +31,31 @@ type Annotation struct {
 	Value string `json:"value"`
 }
 
+// AnnotationMatch is a condition on the annotation stored against a label.
+type AnnotationMatch struct {
+	Name string `json:"name"`
+	// if true only match nodes with the annotation, if false only nodes without
+	Exists *bool `json:"exists"`
+	// only match nodes where the annotation is exactly this value
+	Value *string `json:"value"`
+}
+
+// BigIntMatch compares a value against each bound that is set, all of them must
+// hold. combine a lower and upper bound to match a range, eg {gte: "0x1", lt: "0xa"}
+type BigIntMatch struct {
+	Eq  *big.Int `json:"eq"`
+	Gt  *big.Int `json:"gt"`
+	Gte *big.Int `json:"gte"`
+	Lt  *big.Int `json:"lt"`
+	Lte *big.Int `json:"lte"`
+}
+
 type BlockEvent struct {
 	ID        string   `json:"id"`
 	Block     int      `json:"block"`
 	Sigs      []string `json:"sigs"`
 	Simulated bool     `json:"simulated"`
+	Rollback  bool     `json:"rollback"`
 }
 
 func (BlockEvent) IsEvent() {}
@@ -45,6 +66,20 @@ type ContractConfig struct {
 	Address string `json:"address"`
 }
 
+// DataMatch is a condition on the node data stored against a label.
+//
+// data values are 32 bytes and are compared as unsigned integers, or as sign
+// extended int256 values when signed is true. unset data compares as zero, the
+// same as reading it on-chain.
+type DataMatch struct {
+	Name string `json:"name"`
+	// if true only match nodes with data set for the label, if false only nodes
+	// without
+	Exists *bool        `json:"exists"`
+	Value  *BigIntMatch `json:"value"`
+	Signed *bool        `json:"signed"`
+}
+
 type Dispatcher struct {
 	ID string `json:"id"`
 }
@@ -67,6 +102,26 @@ type ERC721Metadata struct {
 	Attributes      []*ERC721Attribute `json:"attributes"`
 }
 
+// EdgeConnection is a page of edges, see NodeConnection.
+type EdgeConnection struct {
+	Edges    []*EdgeConnectionEdge `json:"edges"`
+	PageInfo *PageInfo             `json:"pageInfo"`
+	// the number of edges that matched, across every page
+	TotalCount int `json:"totalCount"`
+}
+
+type EdgeConnectionEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Edge  `json:"node"`
+}
+
+// EdgeOrder sorts edges by the field, ties are broken by id so the order is
+// always the same for the same graph
+type EdgeOrder struct {
+	Field     EdgeOrderField  `json:"field"`
+	Direction *OrderDirection `json:"direction"`
+}
+
 // match condition for traversing/filtering the graph.
 type Match struct {
 	// ids only match if node is any of these ids, if empty match any id
@@ -83,7 +138,22 @@ type Match struct {
 	//
 	// match(via: ["HAS_RED", "HAS_BLUE"]) would return B,C,Y,Z
 	// match(via: ["HAS_RED", "HAS_BLUE"], has: ["HAS_RED"]) would return B,Z
+	//
+	// when traversing, the edge that reached the node must match one of the
+	// rels, but edges that do not are still followed. when matching nodes
+	// directly (eg State.nodes) the node must have an edge of its own that
+	// matches one of the rels, with dir relative to that node.
 	Has []*RelMatch `json:"has"`
+	// data only matches nodes whose node data satisfies all of the conditions.
+	// like has, edges to nodes that do not match are still followed.
+	Data []*DataMatch `json:"data"`
+	// annotations only matches nodes whose annotations satisfy all of the
+	// conditions. like has, edges to nodes that do not match are still followed.
+	Annotations []*AnnotationMatch `json:"annotations"`
+	// weight only matches edges with a weight within the bounds, decoded the
+	// same way as Edge.weight. like has, edges that do not match are still
+	// followed. it is ignored when matching nodes directly (eg State.nodes).
+	Weight *BigIntMatch `json:"weight"`
 	// `limit` stops matches after that many edges have been collected
 	Limit *int `json:"limit"`
 	// how many connections of connections allow to follow when searching
@@ -91,11 +161,35 @@ type Match struct {
 	MaxDepth *int `json:"maxDepth"`
 }
 
-// node data is an on-chain 32byte value stored as a key value pair for a given node
-type NodeData struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Value string `json:"value"`
+// NodeConnection is a page of nodes. cursors hold the position of a node in
+// the order rather than the node itself, so paging continues from the same
+// place even if that node is removed between requests.
+type NodeConnection struct {
+	Edges    []*NodeConnectionEdge `json:"edges"`
+	PageInfo *PageInfo             `json:"pageInfo"`
+	// the number of nodes that matched, across every page
+	TotalCount int `json:"totalCount"`
+}
+
+type NodeConnectionEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Node  `json:"node"`
+}
+
+// NodeOrder sorts nodes by the field, ties are broken by id so the order is
+// always the same for the same graph
+type NodeOrder struct {
+	Field     NodeOrderField  `json:"field"`
+	Direction *OrderDirection `json:"direction"`
+}
+
+// PageInfo describes a page of a connection, pass endCursor as `after` to fetch
+// the next page.
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
 }
 
 // RelMatch configures the types of edges that can be matched.
@@ -119,17 +213,42 @@ type Router struct {
 }
 
 type SessionScope struct {
-	FullAccess bool `json:"FullAccess"`
+	FullAccess bool     `json:"FullAccess"`
+	Granted    []string `json:"granted"`
+	Bits       string   `json:"bits"`
 }
 
 type State struct {
 	ID        string `json:"id"`
 	Block     int    `json:"block"`
 	Simulated bool   `json:"simulated"`
-	// nodes returns any nodes that match the Match filter.
+	// finalized state only includes blocks that are deep enough (or tagged as
+	// finalized by the chain) that they can no longer be reorged
+	Finalized bool `json:"finalized"`
+	// nodes returns any nodes that match the Match filter, ordered by id.
 	Nodes []*Node `json:"nodes"`
+	// nodesConnection pages through the nodes that match the Match filter.
+	// `first` limits the page size and `after` continues from the endCursor of
+	// the previous page, defaults to every node ordered by id. match.limit
+	// keeps only the first nodes in that order.
+	NodesConnection *NodeConnection `json:"nodesConnection"`
 	// node returns the first node that mates the Match filter.
 	Node *Node `json:"node"`
+	// path returns the shortest route from one node to another as the edges to
+	// follow in order, or null if there is no route. an empty list is returned
+	// when from and to are the same node.
+	//
+	// only edges allowed by the match's `via` rels (and their directions) are
+	// followed, and only nodes allowed by its `ids` and `kinds` are passed
+	// through, the `to` node is always allowed. `maxDepth` works as it does
+	// when traversing, so the path has at most maxDepth+1 edges, unlimited when
+	// unset. the other match conditions are ignored.
+	//
+	// by default the path with the fewest edges is returned, with `weighted` it
+	// is the path with the lowest total edge weight instead (decoded the same
+	// way as Edge.weight), it is an error for the search to meet a negative
+	// weight.
+	Path []*Edge `json:"path"`
 }
 
 type ActionTransactionStatus string
@@ -282,6 +401,136 @@ func (e AttributeKind) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type EdgeOrderField string
+
+const (
+	// the id of the edge, then the direction it was followed
+	EdgeOrderFieldID EdgeOrderField = "ID"
+	// the kind name of the node at the other end of the edge
+	EdgeOrderFieldKind   EdgeOrderField = "KIND"
+	EdgeOrderFieldWeight EdgeOrderField = "WEIGHT"
+	EdgeOrderFieldKey    EdgeOrderField = "KEY"
+)
+
+var AllEdgeOrderField = []EdgeOrderField{
+	EdgeOrderFieldID,
+	EdgeOrderFieldKind,
+	EdgeOrderFieldWeight,
+	EdgeOrderFieldKey,
+}
+
+func (e EdgeOrderField) IsValid() bool {
+	switch e {
+	case EdgeOrderFieldID, EdgeOrderFieldKind, EdgeOrderFieldWeight, EdgeOrderFieldKey:
+		return true
+	}
+	return false
+}
+
+func (e EdgeOrderField) String() string {
+	return string(e)
+}
+
+func (e *EdgeOrderField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EdgeOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EdgeOrderField", str)
+	}
+	return nil
+}
+
+func (e EdgeOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type NodeOrderField string
+
+const (
+	NodeOrderFieldID NodeOrderField = "ID"
+	// the kind name of the node
+	NodeOrderFieldKind NodeOrderField = "KIND"
+)
+
+var AllNodeOrderField = []NodeOrderField{
+	NodeOrderFieldID,
+	NodeOrderFieldKind,
+}
+
+func (e NodeOrderField) IsValid() bool {
+	switch e {
+	case NodeOrderFieldID, NodeOrderFieldKind:
+		return true
+	}
+	return false
+}
+
+func (e NodeOrderField) String() string {
+	return string(e)
+}
+
+func (e *NodeOrderField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NodeOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NodeOrderField", str)
+	}
+	return nil
+}
+
+func (e NodeOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type OrderDirection string
+
+const (
+	OrderDirectionAsc  OrderDirection = "ASC"
+	OrderDirectionDesc OrderDirection = "DESC"
+)
+
+var AllOrderDirection = []OrderDirection{
+	OrderDirectionAsc,
+	OrderDirectionDesc,
+}
+
+func (e OrderDirection) IsValid() bool {
+	switch e {
+	case OrderDirectionAsc, OrderDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e OrderDirection) String() string {
+	return string(e)
+}
+
+func (e *OrderDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderDirection", str)
+	}
+	return nil
+}
+
+func (e OrderDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // RelMatchDirection indicates a direction of the relationship to match.  Edges
 // are directional (they have a src node on one end and a dst node on the other)
 // Sometimes we want to traverse the graph following this direction, sometimes we
@@ -329,3 +578,46 @@ func (e *RelMatchDirection) UnmarshalGQL(v interface{}) error {
 func (e RelMatchDirection) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type SessionStatus string
+
+const (
+	SessionStatusActive  SessionStatus = "ACTIVE"
+	SessionStatusExpired SessionStatus = "EXPIRED"
+	SessionStatusRevoked SessionStatus = "REVOKED"
+)
+
+var AllSessionStatus = []SessionStatus{
+	SessionStatusActive,
+	SessionStatusExpired,
+	SessionStatusRevoked,
+}
+
+func (e SessionStatus) IsValid() bool {
+	switch e {
+	case SessionStatusActive, SessionStatusExpired, SessionStatusRevoked:
+		return true
+	}
+	return false
+}
+
+func (e SessionStatus) String() string {
+	return string(e)
+}
+
+func (e *SessionStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SessionStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SessionStatus", str)
+	}
+	return nil
+}
+
+func (e SessionStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -0,0 +1,211 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// orderKey is the position of a node or edge in an order. Cursors hold the
+// key rather than the item so that paging carries on from the same place
+// even if the item has since been removed from the graph.
+type orderKey struct {
+	Field string   `json:"f"`
+	Str   string   `json:"s,omitempty"`
+	Num   *big.Int `json:"n,omitempty"`
+	ID    string   `json:"i"`
+}
+
+func (k *orderKey) compare(other *orderKey) int {
+	c := 0
+	if k.Num != nil && other.Num != nil {
+		c = k.Num.Cmp(other.Num)
+	} else {
+		c = strings.Compare(k.Str, other.Str)
+	}
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(k.ID, other.ID)
+}
+
+func (k *orderKey) cursor() string {
+	b, _ := json.Marshal(k)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(cursor string, field string) (*orderKey, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	var k orderKey
+	if err := json.Unmarshal(b, &k); err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	if k.Field != field {
+		return nil, fmt.Errorf("cursor %q is for a different orderBy", cursor)
+	}
+	return &k, nil
+}
+
+// unlimited returns a copy of the match without its limit. connections
+// apply the limit once the results are ordered, so that it keeps the first
+// results in that order rather than whichever were found first.
+func unlimited(match *Match) *Match {
+	if match == nil || match.Limit == nil {
+		return match
+	}
+	m := *match
+	m.Limit = nil
+	return &m
+}
+
+// paginate sorts the keys, keeps the first limit of them, and returns the
+// indexes of the page of at most first keys that come after the cursor,
+// along with the number kept and the page info
+func paginate(keys []*orderKey, field string, desc bool, limit *int, first *int, after *string) ([]int, int, *PageInfo, error) {
+	if first != nil && *first < 0 {
+		return nil, 0, nil, fmt.Errorf("first must not be negative")
+	}
+	cmp := func(a, b *orderKey) int {
+		if desc {
+			return b.compare(a)
+		}
+		return a.compare(b)
+	}
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return cmp(keys[order[i]], keys[order[j]]) < 0
+	})
+	if limit != nil && *limit >= 0 && *limit < len(order) {
+		order = order[:*limit]
+	}
+	start := 0
+	if after != nil {
+		from, err := decodeCursor(*after, field)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		start = sort.Search(len(order), func(i int) bool {
+			return cmp(keys[order[i]], from) > 0
+		})
+	}
+	end := len(order)
+	if first != nil && start+*first < end {
+		end = start + *first
+	}
+	info := &PageInfo{
+		HasPreviousPage: start > 0,
+		HasNextPage:     end < len(order),
+	}
+	if end > start {
+		startCursor := keys[order[start]].cursor()
+		endCursor := keys[order[end-1]].cursor()
+		info.StartCursor = &startCursor
+		info.EndCursor = &endCursor
+	}
+	return order[start:end], len(order), info, nil
+}
+
+func nodeOrderKey(n *Node, field NodeOrderField) *orderKey {
+	k := &orderKey{Field: string(field), ID: n.ID}
+	if field == NodeOrderFieldKind {
+		k.Str = n.Kind()
+	}
+	return k
+}
+
+func edgeOrderKey(e *Edge, field EdgeOrderField) *orderKey {
+	k := &orderKey{Field: string(field), ID: fmt.Sprintf("%s-%s", e.ID(), e.Dir)}
+	switch field {
+	case EdgeOrderFieldKind:
+		k.Str = e.Node().Kind()
+	case EdgeOrderFieldWeight:
+		k.Num = e.Weight()
+	case EdgeOrderFieldKey:
+		k.Num = big.NewInt(int64(e.Key()))
+	}
+	return k
+}
+
+// GetNodesConnection returns a page of the nodes that match, ordered by id
+// unless orderBy says otherwise
+func (g *Graph) GetNodesConnection(match *Match, first *int, after *string, orderBy *NodeOrder) (*NodeConnection, error) {
+	field := NodeOrderFieldID
+	desc := false
+	if orderBy != nil {
+		field = orderBy.Field
+		desc = orderBy.Direction != nil && *(orderBy.Direction) == OrderDirectionDesc
+	}
+	nodes := g.GetNodes(unlimited(match))
+	keys := make([]*orderKey, len(nodes))
+	for i, node := range nodes {
+		keys[i] = nodeOrderKey(node, field)
+	}
+	var limit *int
+	if match != nil {
+		limit = match.Limit
+	}
+	indexes, total, info, err := paginate(keys, string(field), desc, limit, first, after)
+	if err != nil {
+		return nil, err
+	}
+	conn := &NodeConnection{
+		Edges:      make([]*NodeConnectionEdge, len(indexes)),
+		PageInfo:   info,
+		TotalCount: total,
+	}
+	for i, idx := range indexes {
+		conn.Edges[i] = &NodeConnectionEdge{
+			Cursor: keys[idx].cursor(),
+			Node:   nodes[idx],
+		}
+	}
+	return conn, nil
+}
+
+// EdgesConnection returns a page of the edges that Edges would return,
+// ordered by id unless orderBy says otherwise
+func (n *Node) EdgesConnection(match *Match, first *int, after *string, orderBy *EdgeOrder) (*EdgeConnection, error) {
+	field := EdgeOrderFieldID
+	desc := false
+	if orderBy != nil {
+		field = orderBy.Field
+		desc = orderBy.Direction != nil && *(orderBy.Direction) == OrderDirectionDesc
+	}
+	edges, err := n.Edges(unlimited(match))
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*orderKey, len(edges))
+	for i, edge := range edges {
+		keys[i] = edgeOrderKey(edge, field)
+	}
+	var limit *int
+	if match != nil {
+		limit = match.Limit
+	}
+	indexes, total, info, err := paginate(keys, string(field), desc, limit, first, after)
+	if err != nil {
+		return nil, err
+	}
+	conn := &EdgeConnection{
+		Edges:      make([]*EdgeConnectionEdge, len(indexes)),
+		PageInfo:   info,
+		TotalCount: total,
+	}
+	for i, idx := range indexes {
+		conn.Edges[i] = &EdgeConnectionEdge{
+			Cursor: keys[idx].cursor(),
+			Node:   edges[idx],
+		}
+	}
+	return conn, nil
+}
@@ -0,0 +1,254 @@
+package model
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func connectionNodeIDs(conn *NodeConnection) []string {
+	ids := []string{}
+	for _, e := range conn.Edges {
+		ids = append(ids, e.Node.ID)
+	}
+	return ids
+}
+
+func TestGetNodesConnection(t *testing.T) {
+	// ids sort in the order they are listed, kinds in the opposite order
+	a1 := testNodeID(1, 1)
+	a2 := testNodeID(1, 2)
+	b1 := testNodeID(2, 1)
+	b2 := testNodeID(2, 2)
+	g := testKind(testKind(NewGraph(0), 1, "Zebra"), 2, "Ant")
+	for _, id := range []string{b2, a1, b1, a2} {
+		g = g.SetData(id, "hp", "0x01", 1)
+	}
+	first := func(n int) *int { return &n }
+	desc := OrderDirectionDesc
+	byKind := &NodeOrder{Field: NodeOrderFieldKind}
+	byIDDesc := &NodeOrder{Field: NodeOrderFieldID, Direction: &desc}
+
+	tests := []struct {
+		name      string
+		match     *Match
+		orderBy   *NodeOrder
+		first     *int
+		want      []string
+		wantTotal int
+		wantNext  bool
+	}{
+		{
+			name:      "every node by id",
+			want:      []string{a1, a2, b1, b2},
+			wantTotal: 4,
+		},
+		{
+			name:      "first page",
+			first:     first(3),
+			want:      []string{a1, a2, b1},
+			wantTotal: 4,
+			wantNext:  true,
+		},
+		{
+			name:      "descending",
+			orderBy:   byIDDesc,
+			want:      []string{b2, b1, a2, a1},
+			wantTotal: 4,
+		},
+		{
+			name:      "by kind then id",
+			orderBy:   byKind,
+			want:      []string{b1, b2, a1, a2},
+			wantTotal: 4,
+		},
+		{
+			name:      "limit keeps the first in order",
+			match:     &Match{Limit: first(2)},
+			orderBy:   byIDDesc,
+			want:      []string{b2, b1},
+			wantTotal: 2,
+		},
+		{
+			name:      "limit and first",
+			match:     &Match{Limit: first(3)},
+			first:     first(2),
+			want:      []string{a1, a2},
+			wantTotal: 3,
+			wantNext:  true,
+		},
+		{
+			name:      "empty page",
+			first:     first(0),
+			want:      []string{},
+			wantTotal: 4,
+			wantNext:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := g.GetNodesConnection(tt.match, tt.first, nil, tt.orderBy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := connectionNodeIDs(conn); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			if conn.TotalCount != tt.wantTotal {
+				t.Fatalf("got total %d, want %d", conn.TotalCount, tt.wantTotal)
+			}
+			if conn.PageInfo.HasNextPage != tt.wantNext || conn.PageInfo.HasPreviousPage {
+				t.Fatalf("got next %v previous %v, want next %v", conn.PageInfo.HasNextPage, conn.PageInfo.HasPreviousPage, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestGetNodesConnectionPaging(t *testing.T) {
+	ids := []string{}
+	g := NewGraph(0)
+	for key := uint64(1); key <= 5; key++ {
+		id := testNodeID(1, key)
+		ids = append(ids, id)
+		g = g.SetData(id, "hp", "0x01", 1)
+	}
+	pageSize := 2
+	got := []string{}
+	var after *string
+	for pages := 0; ; pages++ {
+		if pages > len(ids) {
+			t.Fatal("paging did not finish")
+		}
+		conn, err := g.GetNodesConnection(nil, &pageSize, after, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, connectionNodeIDs(conn)...)
+		if conn.PageInfo.HasPreviousPage != (after != nil) {
+			t.Fatalf("page %d has previous %v", pages, conn.PageInfo.HasPreviousPage)
+		}
+		if !conn.PageInfo.HasNextPage {
+			break
+		}
+		after = conn.PageInfo.EndCursor
+	}
+	if !reflect.DeepEqual(got, ids) {
+		t.Fatalf("got %v, want %v", got, ids)
+	}
+
+	// a cursor for a node that has gone still continues from its position
+	removed := nodeOrderKey(&Node{ID: testNodeID(1, 3)}, NodeOrderFieldID).cursor()
+	conn, err := g.GetNodesConnection(&Match{Ids: []string{ids[0], ids[1], ids[3], ids[4]}}, nil, &removed, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := connectionNodeIDs(conn); !reflect.DeepEqual(got, ids[3:]) {
+		t.Fatalf("after removed node got %v, want %v", got, ids[3:])
+	}
+}
+
+func TestGetNodesConnectionErrors(t *testing.T) {
+	g := NewGraph(0).SetData(testNodeID(1, 1), "hp", "0x01", 1)
+	negative := -1
+	notBase64 := "!"
+	byID := nodeOrderKey(&Node{ID: testNodeID(1, 1)}, NodeOrderFieldID).cursor()
+	tests := []struct {
+		name    string
+		first   *int
+		after   *string
+		orderBy *NodeOrder
+	}{
+		{name: "negative first", first: &negative},
+		{name: "invalid cursor", after: &notBase64},
+		{name: "cursor for another order", after: &byID, orderBy: &NodeOrder{Field: NodeOrderFieldKind}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := g.GetNodesConnection(nil, tt.first, tt.after, tt.orderBy); err == nil {
+				t.Fatal("got no error")
+			}
+		})
+	}
+}
+
+func TestEdgesConnection(t *testing.T) {
+	a := testNodeID(1, 1)
+	g, rel := testRel(NewGraph(0), 1, "Owns", WeightKindInt64)
+	weights := []int64{5, -3, 9, 0}
+	for key, weight := range weights {
+		g = g.SetEdge(rel, uint8(key), a, testNodeID(2, uint64(key)), twos(weight), 1)
+	}
+	desc := OrderDirectionDesc
+	limit := 2
+	tests := []struct {
+		name      string
+		match     *Match
+		orderBy   *EdgeOrder
+		want      []int64
+		wantTotal int
+	}{
+		{
+			name:      "by weight",
+			orderBy:   &EdgeOrder{Field: EdgeOrderFieldWeight},
+			want:      []int64{-3, 0, 5, 9},
+			wantTotal: 4,
+		},
+		{
+			name:      "by weight descending",
+			orderBy:   &EdgeOrder{Field: EdgeOrderFieldWeight, Direction: &desc},
+			want:      []int64{9, 5, 0, -3},
+			wantTotal: 4,
+		},
+		{
+			name:      "by key",
+			orderBy:   &EdgeOrder{Field: EdgeOrderFieldKey},
+			want:      weights,
+			wantTotal: 4,
+		},
+		{
+			name:      "limit keeps the heaviest",
+			match:     &Match{Limit: &limit},
+			orderBy:   &EdgeOrder{Field: EdgeOrderFieldWeight, Direction: &desc},
+			want:      []int64{9, 5},
+			wantTotal: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := g.get(a).EdgesConnection(tt.match, nil, nil, tt.orderBy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []int64{}
+			for _, e := range conn.Edges {
+				got = append(got, e.Node.Weight().Int64())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			if conn.TotalCount != tt.wantTotal {
+				t.Fatalf("got total %d, want %d", conn.TotalCount, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestOrderKeyCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *orderKey
+		want int
+	}{
+		{name: "numbers", a: &orderKey{Num: big.NewInt(-1), ID: "b"}, b: &orderKey{Num: big.NewInt(2), ID: "a"}, want: -1},
+		{name: "strings", a: &orderKey{Str: "b", ID: "a"}, b: &orderKey{Str: "a", ID: "b"}, want: 1},
+		{name: "ties broken by id", a: &orderKey{Str: "a", ID: "a"}, b: &orderKey{Str: "a", ID: "b"}, want: -1},
+		{name: "equal", a: &orderKey{Num: big.NewInt(1), ID: "a"}, b: &orderKey{Num: big.NewInt(1), ID: "a"}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.compare(tt.b); got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package model
+
+import (
+	"container/heap"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// pathStep is a node reached while searching for a path, along with the
+// edge followed to get there from the previous step
+type pathStep struct {
+	node string
+	edge *Edge
+	prev *pathStep
+	hops int
+	cost *big.Int
+	seq  int
+}
+
+// edges returns the edges followed from the start of the search to the step
+func (s *pathStep) edges() []*Edge {
+	edges := make([]*Edge, s.hops)
+	for step := s; step.edge != nil; step = step.prev {
+		edges[step.hops-1] = step.edge
+	}
+	return edges
+}
+
+// pathQueue orders steps by cost, then hops, then the order they were found
+// so that ties always resolve the same way
+type pathQueue []*pathStep
+
+func (q pathQueue) Len() int { return len(q) }
+
+func (q pathQueue) Less(i, j int) bool {
+	if c := q[i].cost.Cmp(q[j].cost); c != 0 {
+		return c < 0
+	}
+	if q[i].hops != q[j].hops {
+		return q[i].hops < q[j].hops
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q pathQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *pathQueue) Push(x interface{}) { *q = append(*q, x.(*pathStep)) }
+
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	step := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return step
+}
+
+// GetPath returns the edges to follow from one node to reach another by the
+// shortest route, or nil if there is no route. Edges are followed if they
+// pass match.MatchVia and lead to a node that passes match.MatchNode (or is
+// the destination). Unweighted searches find the fewest edges, weighted
+// searches the lowest total weight.
+func (g *Graph) GetPath(from string, to string, match *Match, weighted bool) ([]*Edge, error) {
+	from = strings.ToLower(from)
+	to = strings.ToLower(to)
+	if !g.NodeExists(from) || !g.NodeExists(to) {
+		return nil, nil
+	}
+	if from == to {
+		return []*Edge{}, nil
+	}
+	maxHops := -1
+	if match != nil && match.MaxDepth != nil {
+		maxHops = *(match.MaxDepth) + 1
+	}
+	if weighted {
+		return g.cheapestPath(from, to, match, maxHops)
+	}
+	return g.shortestPath(from, to, match, maxHops), nil
+}
+
+// pathEdges returns the edges from the node that a path may follow
+func (g *Graph) pathEdges(id string, to string, match *Match) []*Edge {
+	edges := []*Edge{}
+	for _, e := range g.get(id).getDirectEdges(nil) {
+		if !match.MatchVia(e) {
+			continue
+		}
+		if next := e.Node(); next.ID != to && !match.MatchNode(next) {
+			continue
+		}
+		edges = append(edges, e)
+	}
+	return edges
+}
+
+// shortestPath is a breadth first search for the path with the fewest edges
+func (g *Graph) shortestPath(from string, to string, match *Match, maxHops int) []*Edge {
+	visited := map[string]bool{from: true}
+	queue := []*pathStep{{node: from}}
+	for len(queue) > 0 {
+		step := queue[0]
+		queue = queue[1:]
+		if maxHops >= 0 && step.hops >= maxHops {
+			continue
+		}
+		for _, e := range g.pathEdges(step.node, to, match) {
+			next := e.Node().ID
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			nextStep := &pathStep{
+				node: next,
+				edge: e,
+				prev: step,
+				hops: step.hops + 1,
+			}
+			if next == to {
+				return nextStep.edges()
+			}
+			queue = append(queue, nextStep)
+		}
+	}
+	return nil
+}
+
+// cheapestPath is a dijkstra search for the path with the lowest total edge
+// weight. when the number of edges is limited the same node may be worth
+// visiting again with fewer hops, so nodes are only settled per hop count.
+func (g *Graph) cheapestPath(from string, to string, match *Match, maxHops int) ([]*Edge, error) {
+	settledKey := func(step *pathStep) string {
+		if maxHops < 0 {
+			return step.node
+		}
+		return fmt.Sprintf("%s-%d", step.node, step.hops)
+	}
+	settled := map[string]bool{}
+	queue := &pathQueue{{node: from, cost: big.NewInt(0)}}
+	seq := 0
+	for queue.Len() > 0 {
+		step := heap.Pop(queue).(*pathStep)
+		if step.node == to {
+			return step.edges(), nil
+		}
+		if settled[settledKey(step)] {
+			continue
+		}
+		settled[settledKey(step)] = true
+		if maxHops >= 0 && step.hops >= maxHops {
+			continue
+		}
+		for _, e := range g.pathEdges(step.node, to, match) {
+			weight := e.Weight()
+			if weight.Sign() < 0 {
+				return nil, fmt.Errorf("path: edge %v has negative weight %v", e.ID(), weight)
+			}
+			seq++
+			heap.Push(queue, &pathStep{
+				node: e.Node().ID,
+				edge: e,
+				prev: step,
+				hops: step.hops + 1,
+				cost: new(big.Int).Add(step.cost, weight),
+				seq:  seq,
+			})
+		}
+	}
+	return nil, nil
+}
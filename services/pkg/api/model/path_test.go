@@ -0,0 +1,82 @@
+package model
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestGetPath(t *testing.T) {
+	a := testNodeID(1, 1)
+	b := testNodeID(1, 2)
+	c := testNodeID(1, 3)
+	d := testNodeID(1, 4)
+	x := testNodeID(2, 1)
+	names := map[string]string{a: "a", b: "b", c: "c", d: "d", x: "x"}
+
+	// a -> b -> c -> d costs 3, the shortcut a -> d costs 100, and x is
+	// a different kind with a cheap route through it
+	g := testKind(testKind(NewGraph(0), 1, "Town"), 2, "Toll")
+	g, road := testRel(g, 1, "Road", WeightKindUint64)
+	g, ferry := testRel(g, 2, "Ferry", WeightKindUint64)
+	g = g.SetEdge(road, 0, a, b, big.NewInt(1), 1)
+	g = g.SetEdge(road, 0, b, c, big.NewInt(1), 1)
+	g = g.SetEdge(road, 0, c, d, big.NewInt(1), 1)
+	g = g.SetEdge(road, 1, a, d, big.NewInt(100), 1)
+	g = g.SetEdge(ferry, 0, b, x, big.NewInt(0), 1)
+	g = g.SetEdge(ferry, 0, x, d, big.NewInt(0), 1)
+
+	zero, one := 0, 1
+	out := RelMatchDirectionOut
+	roads := []*RelMatch{{Rel: "Road", Dir: &out}}
+	tests := []struct {
+		name     string
+		from, to string
+		match    *Match
+		weighted bool
+		want     []string
+	}{
+		{name: "fewest edges", from: a, to: d, want: []string{"a", "d"}},
+		{name: "lowest weight", from: a, to: d, weighted: true, want: []string{"a", "b", "x", "d"}},
+		{name: "lowest weight by road", from: a, to: d, match: &Match{Via: roads}, weighted: true, want: []string{"a", "b", "c", "d"}},
+		{name: "lowest weight avoiding a kind", from: a, to: d, match: &Match{Kinds: []string{"Town"}}, weighted: true, want: []string{"a", "b", "c", "d"}},
+		{name: "lowest weight within depth", from: a, to: d, match: &Match{MaxDepth: &one}, weighted: true, want: []string{"a", "d"}},
+		{name: "fewest edges within depth", from: a, to: c, match: &Match{MaxDepth: &zero}, want: nil},
+		{name: "reverse follows in edges", from: d, to: a, want: []string{"d", "a"}},
+		{name: "reverse by out edges only", from: d, to: a, match: &Match{Via: roads}, want: nil},
+		{name: "same node", from: a, to: a, want: []string{"a"}},
+		{name: "unknown node", from: a, to: testNodeID(9, 9), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edges, err := g.GetPath(tt.from, tt.to, tt.match, tt.weighted)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			if edges != nil {
+				got = []string{names[tt.from]}
+				for _, e := range edges {
+					got = append(got, names[e.Node().ID])
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPathNegativeWeight(t *testing.T) {
+	a := testNodeID(1, 1)
+	b := testNodeID(1, 2)
+	g, rel := testRel(NewGraph(0), 1, "Road", WeightKindInt64)
+	g = g.SetEdge(rel, 0, a, b, twos(-1), 1)
+	if _, err := g.GetPath(a, b, nil, true); err == nil {
+		t.Fatal("got no error for a negative weight")
+	}
+	edges, err := g.GetPath(a, b, nil, false)
+	if err != nil || len(edges) != 1 {
+		t.Fatalf("got %v %v, want the unweighted path", edges, err)
+	}
+}
@@ -3,7 +3,9 @@ package model
 type Session struct {
 	ID            string        `json:"id"`
 	Owner         string        `json:"owner"`
-	Scope         *SessionScope `json:"scope"`
+	Scopes        uint32        `json:"scopes"`
 	Expires       int           `json:"expires"`
+	Revoked       bool          `json:"revoked"`
+	Status        SessionStatus `json:"status"`
 	RouterAddress string
 }
@@ -0,0 +1,193 @@
+package model
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/benbjohnson/immutable"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/playmint/ds-node/pkg/contracts/state"
+)
+
+// GraphSnapshot is a plain copy of everything held in a Graph that can be
+// serialised and later turned back into a Graph with NewGraphFromSnapshot
+type GraphSnapshot struct {
+	Block       uint64                       `json:"block"`
+	Nodes       []string                     `json:"nodes"`
+	Edges       []*EdgeSnapshot              `json:"edges"`
+	Rels        []*RelSnapshot               `json:"rels"`
+	Kinds       []*KindSnapshot              `json:"kinds"`
+	Labels      map[string]map[string]string `json:"labels"`
+	Annotations map[string]string            `json:"annotations"`
+	Data        map[string]map[string]string `json:"data"`
+}
+
+type EdgeSnapshot struct {
+	Rel    string   `json:"rel"`
+	Key    uint8    `json:"key"`
+	Src    string   `json:"src"`
+	Dst    string   `json:"dst"`
+	Weight *big.Int `json:"weight"`
+}
+
+type RelSnapshot struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Kind uint8  `json:"kind"`
+}
+
+type KindSnapshot struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	KeyKind uint8  `json:"keyKind"`
+}
+
+func (g *Graph) Snapshot() *GraphSnapshot {
+	s := &GraphSnapshot{
+		Block:       g.block,
+		Nodes:       []string{},
+		Edges:       []*EdgeSnapshot{},
+		Rels:        []*RelSnapshot{},
+		Kinds:       []*KindSnapshot{},
+		Labels:      map[string]map[string]string{},
+		Annotations: map[string]string{},
+		Data:        map[string]map[string]string{},
+	}
+
+	nodesItr := g.nodes.Iterator()
+	for !nodesItr.Done() {
+		id, _, _ := nodesItr.Next()
+		s.Nodes = append(s.Nodes, id)
+	}
+
+	edgesItr := g.edges.Iterator()
+	for !edgesItr.Done() {
+		_, e, _ := edgesItr.Next()
+		s.Edges = append(s.Edges, &EdgeSnapshot{
+			Rel:    e.rel,
+			Key:    e.key,
+			Src:    e.from,
+			Dst:    e.to,
+			Weight: e.weight,
+		})
+	}
+
+	relsItr := g.rels.Iterator()
+	for !relsItr.Done() {
+		id, rel, _ := relsItr.Next()
+		s.Rels = append(s.Rels, &RelSnapshot{
+			ID:   id,
+			Name: rel.Name,
+			Kind: rel.Kind,
+		})
+	}
+
+	kindsItr := g.kinds.Iterator()
+	for !kindsItr.Done() {
+		id, kind, _ := kindsItr.Next()
+		s.Kinds = append(s.Kinds, &KindSnapshot{
+			ID:      id,
+			Name:    kind.Name,
+			KeyKind: kind.KeyKind,
+		})
+	}
+
+	labelsItr := g.labels.Iterator()
+	for !labelsItr.Done() {
+		nodeID, labels, _ := labelsItr.Next()
+		s.Labels[nodeID] = toStringMap(labels)
+	}
+
+	annItr := g.ann.Iterator()
+	for !annItr.Done() {
+		ref, data, _ := annItr.Next()
+		s.Annotations[ref] = data
+	}
+
+	dataItr := g.nodeData.Iterator()
+	for !dataItr.Done() {
+		nodeID, data, _ := dataItr.Next()
+		s.Data[nodeID] = toStringMap(data)
+	}
+
+	return s
+}
+
+func NewGraphFromSnapshot(s *GraphSnapshot) (*Graph, error) {
+	g := NewGraph(s.Block)
+
+	g.nodes, g.kindNodes = g.addNodes(s.Nodes...)
+
+	for _, e := range s.Edges {
+		edge := &DirectedEdge{
+			from:   e.Src,
+			to:     e.Dst,
+			weight: e.Weight,
+			key:    e.Key,
+			rel:    e.Rel,
+		}
+		prev, _ := g.edges.Get(edge.ID())
+		g.edges = g.edges.Set(edge.ID(), edge)
+		g.outEdges, g.inEdges = g.linkEdge(prev, edge)
+	}
+
+	for _, rel := range s.Rels {
+		id, err := hexutil.Decode(rel.ID)
+		if err != nil || len(id) != 4 {
+			return nil, fmt.Errorf("snapshot: invalid rel id %v", rel.ID)
+		}
+		relData := &state.StateEdgeTypeRegister{
+			Name: rel.Name,
+			Kind: rel.Kind,
+		}
+		copy(relData.Id[:], id)
+		g.rels = g.rels.Set(rel.ID, relData)
+		g.relsByName = g.relsByName.Set(rel.Name, rel.ID)
+	}
+
+	for _, kind := range s.Kinds {
+		id, err := hexutil.Decode(kind.ID)
+		if err != nil || len(id) != 4 {
+			return nil, fmt.Errorf("snapshot: invalid kind id %v", kind.ID)
+		}
+		kindData := &state.StateNodeTypeRegister{
+			Name:    kind.Name,
+			KeyKind: kind.KeyKind,
+		}
+		copy(kindData.Id[:], id)
+		g.kinds = g.kinds.Set(kind.ID, kindData)
+		g.kindsByName = g.kindsByName.Set(kind.Name, kind.ID)
+	}
+
+	for nodeID, labels := range s.Labels {
+		g.labels = g.labels.Set(nodeID, fromStringMap(labels))
+	}
+
+	for ref, data := range s.Annotations {
+		g.ann = g.ann.Set(ref, data)
+	}
+
+	for nodeID, data := range s.Data {
+		g.nodeData = g.nodeData.Set(nodeID, fromStringMap(data))
+	}
+
+	return g, nil
+}
+
+func toStringMap(m *immutable.Map[string, string]) map[string]string {
+	out := map[string]string{}
+	itr := m.Iterator()
+	for !itr.Done() {
+		k, v, _ := itr.Next()
+		out[k] = v
+	}
+	return out
+}
+
+func fromStringMap(m map[string]string) *immutable.Map[string, string] {
+	out := immutable.NewMap[string, string](nil)
+	for k, v := range m {
+		out = out.Set(k, v)
+	}
+	return out
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 
 	"github.com/benbjohnson/immutable"
@@ -28,6 +29,19 @@ const (
 	CompoundKeyKindString
 )
 
+// WeightKind mirrors the enum in IState.sol that an edge type is registered
+// with, it says how to decode the uint160 weights of edges of that type
+type WeightKind uint8
+
+const (
+	WeightKindUint64 WeightKind = iota
+	WeightKindInt64
+	WeightKindBytes
+	WeightKindString
+)
+
+var maxUint64 = new(big.Int).SetUint64(^uint64(0))
+
 type Graph struct {
 	// cache of which nodes we have seen edges between
 	nodes *immutable.Map[string, bool]
@@ -45,43 +59,110 @@ type Graph struct {
 	nodeData *immutable.Map[string, *immutable.Map[string, string]]
 	// block is the last seen update to the graph
 	block uint64
-	// cache of edges by node id
-	edgeCache map[string][]*Edge
+	// nodeID => edgeID => edge, for the edges leaving and entering each
+	// node. updated incrementally by SetEdge/RemoveEdge and shared between
+	// graph versions like everything else.
+	outEdges *immutable.Map[string, *immutable.Map[string, *DirectedEdge]]
+	inEdges  *immutable.Map[string, *immutable.Map[string, *DirectedEdge]]
+	// kindID => nodeID => true, kept up to date by the mutators so that
+	// nodes of a kind can be found without visiting every node
+	kindNodes *immutable.Map[string, *immutable.Map[string, bool]]
+	// mapping of kind name => kindID
+	kindsByName *immutable.Map[string, string]
+	// mapping of rel name => relID
+	relsByName *immutable.Map[string, string]
 }
 
 func NewGraph(block uint64) *Graph {
 	return &Graph{
-		nodes:     immutable.NewMap[string, bool](nil),
-		edges:     immutable.NewMap[string, *DirectedEdge](nil),
-		rels:      immutable.NewMap[string, *state.StateEdgeTypeRegister](nil),
-		kinds:     immutable.NewMap[string, *state.StateNodeTypeRegister](nil),
-		labels:    immutable.NewMap[string, *immutable.Map[string, string]](nil),
-		ann:       immutable.NewMap[string, string](nil),
-		nodeData:  immutable.NewMap[string, *immutable.Map[string, string]](nil),
-		block:     block,
-		edgeCache: map[string][]*Edge{},
-	}
-}
-
-func (g *Graph) updateEdgeCache() {
-	edgesItr := g.edges.Iterator()
-	for !edgesItr.Done() {
-		_, e, exists := edgesItr.Next()
-		if !exists {
+		nodes:       immutable.NewMap[string, bool](nil),
+		edges:       immutable.NewMap[string, *DirectedEdge](nil),
+		rels:        immutable.NewMap[string, *state.StateEdgeTypeRegister](nil),
+		kinds:       immutable.NewMap[string, *state.StateNodeTypeRegister](nil),
+		labels:      immutable.NewMap[string, *immutable.Map[string, string]](nil),
+		ann:         immutable.NewMap[string, string](nil),
+		nodeData:    immutable.NewMap[string, *immutable.Map[string, string]](nil),
+		block:       block,
+		outEdges:    immutable.NewMap[string, *immutable.Map[string, *DirectedEdge]](nil),
+		inEdges:     immutable.NewMap[string, *immutable.Map[string, *DirectedEdge]](nil),
+		kindNodes:   immutable.NewMap[string, *immutable.Map[string, bool]](nil),
+		kindsByName: immutable.NewMap[string, string](nil),
+		relsByName:  immutable.NewMap[string, string](nil),
+	}
+}
+
+// adjacency maps for edges leaving and entering nodes
+type adjacency = immutable.Map[string, *immutable.Map[string, *DirectedEdge]]
+
+func adjacencySet(adj *adjacency, nodeID string, e *DirectedEdge) *adjacency {
+	edges, ok := adj.Get(nodeID)
+	if !ok {
+		edges = immutable.NewMap[string, *DirectedEdge](nil)
+	}
+	return adj.Set(nodeID, edges.Set(e.ID(), e))
+}
+
+func adjacencyDelete(adj *adjacency, nodeID string, e *DirectedEdge) *adjacency {
+	edges, ok := adj.Get(nodeID)
+	if !ok {
+		return adj
+	}
+	edges = edges.Delete(e.ID())
+	if edges.Len() == 0 {
+		return adj.Delete(nodeID)
+	}
+	return adj.Set(nodeID, edges)
+}
+
+// linkEdge adds e to the adjacency maps, replacing prev if the edge is
+// being updated
+func (g *Graph) linkEdge(prev *DirectedEdge, e *DirectedEdge) (out *adjacency, in *adjacency) {
+	out, in = g.unlinkEdge(prev)
+	return adjacencySet(out, e.from, e), adjacencySet(in, e.to, e)
+}
+
+// unlinkEdge removes e from the adjacency maps, e can be nil
+func (g *Graph) unlinkEdge(e *DirectedEdge) (out *adjacency, in *adjacency) {
+	if e == nil {
+		return g.outEdges, g.inEdges
+	}
+	return adjacencyDelete(g.outEdges, e.from, e), adjacencyDelete(g.inEdges, e.to, e)
+}
+
+// addNodes marks the nodes as seen and indexes them by kind
+func (g *Graph) addNodes(ids ...string) (*immutable.Map[string, bool], *immutable.Map[string, *immutable.Map[string, bool]]) {
+	nodes := g.nodes
+	kindNodes := g.kindNodes
+	for _, id := range ids {
+		if _, exists := nodes.Get(id); exists {
 			continue
 		}
+		nodes = nodes.Set(id, true)
+		kindID := nodeKindID(id)
+		ofKind, ok := kindNodes.Get(kindID)
+		if !ok {
+			ofKind = immutable.NewMap[string, bool](nil)
+		}
+		kindNodes = kindNodes.Set(kindID, ofKind.Set(id, true))
+	}
+	return nodes, kindNodes
+}
 
-		g.edgeCache[e.from] = append(g.edgeCache[e.from], &Edge{
-			g:            g,
-			DirectedEdge: e,
-			Dir:          RelMatchDirectionOut,
-		})
-		g.edgeCache[e.to] = append(g.edgeCache[e.to], &Edge{
-			g:            g,
-			DirectedEdge: e,
-			Dir:          RelMatchDirectionIn,
-		})
+// renameIndex points name at id in a name index, dropping prevName if it
+// was the name id was previously registered under
+func renameIndex(byName *immutable.Map[string, string], id string, prevName string, name string) *immutable.Map[string, string] {
+	if prevID, ok := byName.Get(prevName); ok && prevID == id {
+		byName = byName.Delete(prevName)
 	}
+	return byName.Set(name, id)
+}
+
+// nodeKindID returns the kind id prefix of a node id
+func nodeKindID(id string) string {
+	if len(id) < 10 {
+		return id
+	}
+	return strings.ToLower(id[:10])
 }
 
 func (g *Graph) BlockNumber() uint64 {
@@ -89,30 +170,48 @@ func (g *Graph) BlockNumber() uint64 {
 }
 
 func (g *Graph) SetRelData(relData *state.StateEdgeTypeRegister) *Graph {
+	relID := hexutil.Encode(relData.Id[:])
+	prevName := ""
+	if prev, ok := g.rels.Get(relID); ok {
+		prevName = prev.Name
+	}
 	return &Graph{
-		nodes:     g.nodes,
-		edges:     g.edges,
-		rels:      g.rels.Set(hexutil.Encode(relData.Id[:]), relData),
-		kinds:     g.kinds,
-		labels:    g.labels,
-		ann:       g.ann,
-		nodeData:  g.nodeData,
-		block:     g.block,
-		edgeCache: g.edgeCache,
+		nodes:       g.nodes,
+		edges:       g.edges,
+		rels:        g.rels.Set(relID, relData),
+		kinds:       g.kinds,
+		labels:      g.labels,
+		ann:         g.ann,
+		nodeData:    g.nodeData,
+		block:       g.block,
+		outEdges:    g.outEdges,
+		inEdges:     g.inEdges,
+		kindNodes:   g.kindNodes,
+		kindsByName: g.kindsByName,
+		relsByName:  renameIndex(g.relsByName, relID, prevName, relData.Name),
 	}
 }
 
 func (g *Graph) SetKindData(kindData *state.StateNodeTypeRegister) *Graph {
+	kindID := hexutil.Encode(kindData.Id[:])
+	prevName := ""
+	if prev, ok := g.kinds.Get(kindID); ok {
+		prevName = prev.Name
+	}
 	return &Graph{
-		nodes:     g.nodes,
-		edges:     g.edges,
-		rels:      g.rels,
-		kinds:     g.kinds.Set(hexutil.Encode(kindData.Id[:]), kindData),
-		labels:    g.labels,
-		ann:       g.ann,
-		nodeData:  g.nodeData,
-		block:     g.block,
-		edgeCache: g.edgeCache,
+		nodes:       g.nodes,
+		edges:       g.edges,
+		rels:        g.rels,
+		kinds:       g.kinds.Set(kindID, kindData),
+		labels:      g.labels,
+		ann:         g.ann,
+		nodeData:    g.nodeData,
+		block:       g.block,
+		outEdges:    g.outEdges,
+		inEdges:     g.inEdges,
+		kindNodes:   g.kindNodes,
+		kindsByName: renameIndex(g.kindsByName, kindID, prevName, kindData.Name),
+		relsByName:  g.relsByName,
 	}
 }
 
@@ -127,24 +226,25 @@ func (g *Graph) SetAnnotationData(nodeID string, label string, ref string, data
 	labels = labels.Set(label, ref)
 
 	// update the node data to mark the seen nodes
-	nodes := g.nodes
-	nodes = nodes.Set(nodeID, true)
+	nodes, kindNodes := g.addNodes(nodeID)
 
 	// build our new graph
 	newGraph := &Graph{
-		nodes:     nodes,
-		edges:     g.edges,
-		rels:      g.rels,
-		kinds:     g.kinds,
-		labels:    g.labels.Set(nodeID, labels),
-		ann:       ann,
-		nodeData:  g.nodeData,
-		block:     block,
-		edgeCache: map[string][]*Edge{},
+		nodes:       nodes,
+		edges:       g.edges,
+		rels:        g.rels,
+		kinds:       g.kinds,
+		labels:      g.labels.Set(nodeID, labels),
+		ann:         ann,
+		nodeData:    g.nodeData,
+		block:       block,
+		outEdges:    g.outEdges,
+		inEdges:     g.inEdges,
+		kindNodes:   kindNodes,
+		kindsByName: g.kindsByName,
+		relsByName:  g.relsByName,
 	}
 
-	newGraph.updateEdgeCache()
-
 	return newGraph
 }
 
@@ -157,24 +257,25 @@ func (g *Graph) SetData(nodeID string, key string, value string, block uint64) *
 	nodeData = nodeData.Set(key, value)
 
 	// update the node data to mark the seen nodes
-	nodes := g.nodes
-	nodes = nodes.Set(nodeID, true)
+	nodes, kindNodes := g.addNodes(nodeID)
 
 	// build our new graph
 	newGraph := &Graph{
-		nodes:     nodes,
-		edges:     g.edges,
-		rels:      g.rels,
-		kinds:     g.kinds,
-		labels:    g.labels,
-		ann:       g.ann,
-		nodeData:  g.nodeData.Set(nodeID, nodeData),
-		block:     block,
-		edgeCache: map[string][]*Edge{},
+		nodes:       nodes,
+		edges:       g.edges,
+		rels:        g.rels,
+		kinds:       g.kinds,
+		labels:      g.labels,
+		ann:         g.ann,
+		nodeData:    g.nodeData.Set(nodeID, nodeData),
+		block:       block,
+		outEdges:    g.outEdges,
+		inEdges:     g.inEdges,
+		kindNodes:   kindNodes,
+		kindsByName: g.kindsByName,
+		relsByName:  g.relsByName,
 	}
 
-	newGraph.updateEdgeCache()
-
 	return newGraph
 }
 
@@ -189,28 +290,30 @@ func (g *Graph) SetEdge(relID string, relKey uint8, srcNodeID string, dstNodeID
 	}
 
 	// set the edge going in both directions
+	prev, _ := g.edges.Get(e.ID())
 	edges := g.edges.Set(e.ID(), e)
+	outEdges, inEdges := g.linkEdge(prev, e)
 
 	// update the node data to mark the seen nodes
-	nodes := g.nodes
-	nodes = nodes.Set(srcNodeID, true)
-	nodes = nodes.Set(dstNodeID, true)
+	nodes, kindNodes := g.addNodes(srcNodeID, dstNodeID)
 
 	// build our new graph
 	newGraph := &Graph{
-		nodes:     nodes,
-		edges:     edges,
-		rels:      g.rels,
-		kinds:     g.kinds,
-		labels:    g.labels,
-		ann:       g.ann,
-		nodeData:  g.nodeData,
-		block:     block,
-		edgeCache: map[string][]*Edge{},
+		nodes:       nodes,
+		edges:       edges,
+		rels:        g.rels,
+		kinds:       g.kinds,
+		labels:      g.labels,
+		ann:         g.ann,
+		nodeData:    g.nodeData,
+		block:       block,
+		outEdges:    outEdges,
+		inEdges:     inEdges,
+		kindNodes:   kindNodes,
+		kindsByName: g.kindsByName,
+		relsByName:  g.relsByName,
 	}
 
-	newGraph.updateEdgeCache()
-
 	return newGraph
 }
 
@@ -222,54 +325,40 @@ func (g *Graph) RemoveEdge(relID string, relKey uint8, srcNodeID string, block u
 		rel:  relID,
 		key:  relKey,
 	}
+	prev, _ := g.edges.Get(e.ID())
 	edges := g.edges.Delete(e.ID())
+	outEdges, inEdges := g.unlinkEdge(prev)
 
 	// TODO: should we remove nodes from the node list that have no edges connected?
 
 	// build our new graph
 	newGraph := &Graph{
-		nodes:     g.nodes,
-		edges:     edges,
-		rels:      g.rels,
-		kinds:     g.kinds,
-		labels:    g.labels,
-		ann:       g.ann,
-		nodeData:  g.nodeData,
-		block:     block,
-		edgeCache: map[string][]*Edge{},
+		nodes:       g.nodes,
+		edges:       edges,
+		rels:        g.rels,
+		kinds:       g.kinds,
+		labels:      g.labels,
+		ann:         g.ann,
+		nodeData:    g.nodeData,
+		block:       block,
+		outEdges:    outEdges,
+		inEdges:     inEdges,
+		kindNodes:   g.kindNodes,
+		kindsByName: g.kindsByName,
+		relsByName:  g.relsByName,
 	}
 
-	newGraph.updateEdgeCache()
-
 	return newGraph
 }
 
 func (g *Graph) GetRelByName(relName string) string {
-	itr := g.rels.Iterator()
-	for !itr.Done() {
-		relID, relData, ok := itr.Next()
-		if !ok {
-			continue
-		}
-		if relData.Name == relName {
-			return relID
-		}
-	}
-	return ""
+	relID, _ := g.relsByName.Get(relName)
+	return relID
 }
 
 func (g *Graph) GetKindByName(kindName string) string {
-	itr := g.kinds.Iterator()
-	for !itr.Done() {
-		kindID, kindData, ok := itr.Next()
-		if !ok {
-			continue
-		}
-		if kindData.Name == kindName {
-			return kindID
-		}
-	}
-	return ""
+	kindID, _ := g.kindsByName.Get(kindName)
+	return kindID
 }
 
 func (g *Graph) get(id string) *Node {
@@ -297,23 +386,95 @@ func (g *Graph) NodeExists(id string) bool {
 	return exists
 }
 
+// GetNodes returns the nodes that match ordered by id, up to match.Limit
 func (g *Graph) GetNodes(match *Match) []*Node {
 	nodes := []*Node{}
-	itr := g.nodes.Iterator()
-	for !itr.Done() {
-		id, _, exists := itr.Next()
-		if !exists {
-			continue
+	ids := g.candidateNodes(match)
+	sort.Strings(ids)
+	for _, id := range ids {
+		if match.limitReached(len(nodes)) {
+			break
 		}
 		node := g.get(id)
 		if match != nil && !match.MatchNode(node) {
 			continue
 		}
+		if match != nil && !(match.MatchData(node) && match.MatchAnnotations(node) && match.nodeHas(node)) {
+			continue
+		}
 		nodes = append(nodes, node)
 	}
 	return nodes
 }
 
+// candidateNodes narrows down the nodes that could satisfy the match using
+// the ids or the kind index, so that the match only needs to be checked
+// against those rather than every node in the graph
+func (g *Graph) candidateNodes(match *Match) []string {
+	ids := []string{}
+	if match != nil && len(match.Ids) > 0 {
+		seen := map[string]bool{}
+		for _, id := range match.Ids {
+			id = strings.ToLower(id)
+			if _, exists := g.nodes.Get(id); exists && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+	if match != nil && len(match.Kinds) > 0 {
+		kindIDs := map[string]bool{}
+		for _, kind := range match.Kinds {
+			if kindID, ok := g.kindsByName.Get(kind); ok {
+				kindIDs[kindID] = true
+				continue
+			}
+			// kinds match case insensitively, so fall back to searching
+			// when the name is not an exact match
+			kindsItr := g.kinds.Iterator()
+			for !kindsItr.Done() {
+				kindID, kindData, _ := kindsItr.Next()
+				if strings.EqualFold(kind, kindData.Name) {
+					kindIDs[kindID] = true
+				}
+			}
+		}
+		// map iteration order is random, so visit the kinds in a fixed
+		// order to keep the candidates stable between queries
+		sortedKindIDs := make([]string, 0, len(kindIDs))
+		for kindID := range kindIDs {
+			sortedKindIDs = append(sortedKindIDs, kindID)
+		}
+		sort.Strings(sortedKindIDs)
+		for _, kindID := range sortedKindIDs {
+			ofKind, ok := g.kindNodes.Get(kindID)
+			if !ok {
+				continue
+			}
+			itr := ofKind.Iterator()
+			for !itr.Done() {
+				id, _, _ := itr.Next()
+				ids = append(ids, id)
+			}
+		}
+		// nodes of an unregistered kind use their id as their kind
+		for _, kind := range match.Kinds {
+			kind = strings.ToLower(kind)
+			if _, exists := g.nodes.Get(kind); exists && !kindIDs[nodeKindID(kind)] {
+				ids = append(ids, kind)
+			}
+		}
+		return ids
+	}
+	itr := g.nodes.Iterator()
+	for !itr.Done() {
+		id, _, _ := itr.Next()
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 type Node struct {
 	g *Graph
 
@@ -411,6 +572,28 @@ func (n *Node) Data(name string) *NodeData {
 	return nil
 }
 
+// dataValue returns the raw value of the node data for the label
+func (n *Node) dataValue(label string) (string, bool) {
+	dataMap, ok := n.g.nodeData.Get(n.ID)
+	if !ok {
+		return "", false
+	}
+	return dataMap.Get(label)
+}
+
+// annotationValue returns the value of the annotation for the label
+func (n *Node) annotationValue(label string) (string, bool) {
+	labels, ok := n.g.labels.Get(n.ID)
+	if !ok {
+		return "", false
+	}
+	ref, ok := labels.Get(label)
+	if !ok {
+		return "", false
+	}
+	return n.g.ann.Get(ref)
+}
+
 func (n *Node) AllData() []*NodeData {
 	allData := []*NodeData{}
 	dataMap, ok := n.g.nodeData.Get(n.ID)
@@ -424,15 +607,26 @@ func (n *Node) AllData() []*NodeData {
 			continue
 		}
 		allData = append(allData, &NodeData{
-			ID:    fmt.Sprintf("%s-%s", n.ID, key),
-			Name:  key,
-			Value: value,
+			ID:       fmt.Sprintf("%s-%s", n.ID, key),
+			Name:     key,
+			Value:    value,
+			NodeKind: n.Kind(),
 		})
 
 	}
 	return allData
 }
 
+// NodeData is an on-chain 32 byte value stored against a node, the typed
+// fields are decoded by the resolver using the declared type for the label
+type NodeData struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// NodeKind is the kind name of the node the data belongs to
+	NodeKind string `json:"-"`
+}
+
 func (n *Node) Kind() string {
 	if n.kind != "" {
 		return n.kind
@@ -458,19 +652,19 @@ func (n *Node) Count(match *Match) (int, error) {
 	return len(edges), nil
 }
 
-func (n *Node) Sum(match *Match) (int, error) {
+func (n *Node) Sum(match *Match) (*big.Int, error) {
 	edges, err := n.Edges(match)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	sum := 0
+	sum := big.NewInt(0)
 	for _, edge := range edges {
-		sum += edge.Weight()
+		sum.Add(sum, edge.Weight())
 	}
 	return sum, nil
 }
 
-func (n *Node) Value(match *Match) (*int, error) {
+func (n *Node) Value(match *Match) (*big.Int, error) {
 	edge, err := n.Edge(match)
 	if err != nil {
 		return nil, err
@@ -478,8 +672,7 @@ func (n *Node) Value(match *Match) (*int, error) {
 	if edge == nil {
 		return nil, nil
 	}
-	w := edge.Weight()
-	return &w, nil
+	return edge.Weight(), nil
 }
 
 func (n *Node) Node(match *Match) (*Node, error) {
@@ -528,12 +721,36 @@ func (n *Node) Edges(match *Match) ([]*Edge, error) {
 func (n *Node) getDirectEdges(match *Match) []*Edge {
 	result := []*Edge{}
 
-	for _, edge := range n.g.edgeCache[n.ID] {
-		if !match.MatchEdge(edge) {
+	for _, dir := range []RelMatchDirection{RelMatchDirectionOut, RelMatchDirectionIn} {
+		adj := n.g.outEdges
+		if dir == RelMatchDirectionIn {
+			adj = n.g.inEdges
+		}
+		edges, ok := adj.Get(n.ID)
+		if !ok {
 			continue
 		}
-		result = append(result, edge)
+		itr := edges.Iterator()
+		for !itr.Done() {
+			_, e, _ := itr.Next()
+			edge := &Edge{
+				g:            n.g,
+				DirectedEdge: e,
+				Dir:          dir,
+			}
+			if !match.MatchEdge(edge) {
+				continue
+			}
+			result = append(result, edge)
+		}
 	}
+	// order by id so that results do not depend on map iteration
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ID() != result[j].ID() {
+			return result[i].ID() < result[j].ID()
+		}
+		return result[i].Dir < result[j].Dir
+	})
 	return result
 }
 
@@ -542,6 +759,16 @@ func (n *Node) matchEdges(match *Match, depth int, seen map[string]bool) []*Edge
 	seen[n.ID] = true
 	// get all the connections from this node
 	edges := n.getDirectEdges(match)
+	// keep the ones that pass the filters, the rest are still followed
+	matched := []*Edge{}
+	for _, e := range edges {
+		if match.limitReached(len(matched)) {
+			return matched
+		}
+		if match.MatchFilters(e) {
+			matched = append(matched, e)
+		}
+	}
 	// for each edge collect the edges of their node
 	// do this until we hit match.MaxDepth
 	if match != nil && match.MaxDepth != nil && depth < *(match.MaxDepth) {
@@ -551,14 +778,15 @@ func (n *Node) matchEdges(match *Match, depth int, seen map[string]bool) []*Edge
 				continue
 			}
 			seen[next.ID] = true
-			for _, moreEdges := range next.matchEdges(match, depth+1, seen) {
-				edges = append(edges, moreEdges)
+			matched = append(matched, next.matchEdges(match, depth+1, seen)...)
+			if match.limitReached(len(matched)) {
+				return matched[:*(match.Limit)]
 			}
 		}
 	}
 
 	// return what we got
-	return edges
+	return matched
 }
 
 type Edge struct {
@@ -607,11 +835,19 @@ func (e *DirectedEdge) Key() int {
 	return int(e.key)
 }
 
-func (e *DirectedEdge) Weight() int {
+// Weight returns the edge weight decoded according to the WeightKind that
+// its rel was registered with. Signed weights are stored as the two's
+// complement of an int64, every other kind is returned as the unsigned value.
+func (e *Edge) Weight() *big.Int {
 	if e.weight == nil {
-		return 0
+		return big.NewInt(0)
 	}
-	return int(e.weight.Uint64())
+	relData, ok := e.g.rels.Get(e.rel)
+	if ok && WeightKind(relData.Kind) == WeightKindInt64 {
+		low := new(big.Int).And(e.weight, maxUint64)
+		return big.NewInt(int64(low.Uint64()))
+	}
+	return new(big.Int).Set(e.weight)
 }
 
 func (match *Match) MatchNode(n *Node) bool {
@@ -647,33 +883,157 @@ func (match *Match) MatchVia(e *Edge) bool {
 	return true
 }
 
+// limitReached reports whether n matches is enough to satisfy match.Limit
+func (match *Match) limitReached(n int) bool {
+	return match != nil && match.Limit != nil && n >= *(match.Limit)
+}
+
+// MatchFilters checks the conditions that only decide whether an edge is
+// collected. Unlike MatchEdge, an edge that fails them is still followed when
+// traversing.
+func (match *Match) MatchFilters(e *Edge) bool {
+	if match == nil {
+		return true
+	}
+	if !match.MatchHas(e) {
+		return false
+	}
+	if match.Weight != nil && !match.Weight.MatchBigInt(e.Weight()) {
+		return false
+	}
+	node := e.Node()
+	return match.MatchData(node) && match.MatchAnnotations(node)
+}
+
+// MatchHas reports whether the edge matches one of the has rels
+func (match *Match) MatchHas(e *Edge) bool {
+	if match == nil || len(match.Has) == 0 {
+		return true
+	}
+	ok, _ := matchRels(match.Has, e)
+	return ok
+}
+
+// nodeHas reports whether the node has an edge of its own that matches one
+// of the has rels
+func (match *Match) nodeHas(n *Node) bool {
+	if match == nil || len(match.Has) == 0 {
+		return true
+	}
+	for _, e := range n.getDirectEdges(nil) {
+		if match.MatchHas(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchData reports whether the node's data satisfies every data condition
+func (match *Match) MatchData(n *Node) bool {
+	if match == nil {
+		return true
+	}
+	for _, cond := range match.Data {
+		data, exists := n.dataValue(cond.Name)
+		if cond.Exists != nil && *(cond.Exists) != exists {
+			return false
+		}
+		if cond.Value == nil {
+			continue
+		}
+		value := big.NewInt(0)
+		if exists {
+			raw, err := hexutil.Decode(data)
+			if err != nil {
+				return false
+			}
+			value.SetBytes(raw)
+			if cond.Signed != nil && *(cond.Signed) && len(raw) > 0 && raw[0]&0x80 != 0 {
+				value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(len(raw)*8)))
+			}
+		}
+		if !cond.Value.MatchBigInt(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchAnnotations reports whether the node's annotations satisfy every
+// annotation condition
+func (match *Match) MatchAnnotations(n *Node) bool {
+	if match == nil {
+		return true
+	}
+	for _, cond := range match.Annotations {
+		value, exists := n.annotationValue(cond.Name)
+		if cond.Exists != nil && *(cond.Exists) != exists {
+			return false
+		}
+		if cond.Value != nil && (!exists || *(cond.Value) != value) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchBigInt reports whether n satisfies every bound that is set
+func (m *BigIntMatch) MatchBigInt(n *big.Int) bool {
+	if m == nil {
+		return true
+	}
+	if m.Eq != nil && n.Cmp(m.Eq) != 0 {
+		return false
+	}
+	if m.Gt != nil && n.Cmp(m.Gt) <= 0 {
+		return false
+	}
+	if m.Gte != nil && n.Cmp(m.Gte) < 0 {
+		return false
+	}
+	if m.Lt != nil && n.Cmp(m.Lt) >= 0 {
+		return false
+	}
+	if m.Lte != nil && n.Cmp(m.Lte) > 0 {
+		return false
+	}
+	return true
+}
+
 func (match *Match) viaRels(e *Edge) bool {
 	if match.Via == nil {
 		return true
 	}
+	ok, numRels := matchRels(match.Via, e)
+	if numRels == 0 {
+		return true
+	}
+	return ok
+}
+
+// matchRels reports whether the edge matches any of the rels, and how many
+// of the rels are registered so could have matched
+func matchRels(rels []*RelMatch, e *Edge) (bool, int) {
 	numRels := 0
-	for _, via := range match.Via {
-		if via.Rel != "" {
-			matchRelID := e.g.GetRelByName(via.Rel)
+	for _, rel := range rels {
+		if rel.Rel != "" {
+			matchRelID := e.g.GetRelByName(rel.Rel)
 			if matchRelID == "" {
 				continue // invalid
 			}
 			dir := RelMatchDirectionOut
-			if via.Dir != nil {
-				dir = *(via.Dir)
+			if rel.Dir != nil {
+				dir = *(rel.Dir)
 			}
 			numRels++
 			if matchRelID == e.rel &&
-				(via.Key == nil || *(via.Key) == e.Key()) &&
+				(rel.Key == nil || *(rel.Key) == e.Key()) &&
 				(dir == RelMatchDirectionBoth || dir == e.Dir) {
-				return true
+				return true, numRels
 			}
 		}
 	}
-	if numRels == 0 {
-		return true
-	}
-	return false
+	return false, numRels
 }
 
 func contains(s []string, e string) bool {
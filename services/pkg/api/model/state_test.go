@@ -0,0 +1,330 @@
+package model
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/playmint/ds-node/pkg/contracts/state"
+)
+
+// testNodeID returns the id of the node with the key for the kind
+func testNodeID(kind uint32, key uint64) string {
+	id := make([]byte, 24)
+	binary.BigEndian.PutUint32(id[:4], kind)
+	binary.BigEndian.PutUint64(id[16:], key)
+	return hexutil.Encode(id)
+}
+
+func testKind(g *Graph, kind uint32, name string) *Graph {
+	data := &state.StateNodeTypeRegister{Name: name}
+	binary.BigEndian.PutUint32(data.Id[:], kind)
+	return g.SetKindData(data)
+}
+
+// testRel registers the rel and returns its id
+func testRel(g *Graph, rel uint32, name string, kind WeightKind) (*Graph, string) {
+	data := &state.StateEdgeTypeRegister{Name: name, Kind: uint8(kind)}
+	binary.BigEndian.PutUint32(data.Id[:], rel)
+	return g.SetRelData(data), hexutil.Encode(data.Id[:])
+}
+
+func nodeIDs(nodes []*Node) []string {
+	ids := []string{}
+	for _, n := range nodes {
+		ids = append(ids, n.ID)
+	}
+	return ids
+}
+
+func sortedNodeIDs(nodes []*Node) []string {
+	ids := nodeIDs(nodes)
+	sort.Strings(ids)
+	return ids
+}
+
+func sorted(ids ...string) []string {
+	sort.Strings(ids)
+	return ids
+}
+
+func TestGetNodesByKind(t *testing.T) {
+	seeker1 := testNodeID(1, 1)
+	seeker2 := testNodeID(1, 2)
+	tile := testNodeID(2, 1)
+	unregistered := testNodeID(9, 1)
+
+	g := NewGraph(0)
+	g = testKind(g, 1, "Seeker")
+	g = testKind(g, 2, "Tile")
+	for _, id := range []string{seeker1, seeker2, tile, unregistered} {
+		g = g.SetData(id, "name", "0x01", 1)
+	}
+	renamed := testKind(g, 2, "Square")
+
+	tests := []struct {
+		name  string
+		graph *Graph
+		match *Match
+		want  []string
+	}{
+		{
+			name:  "one kind",
+			graph: g,
+			match: &Match{Kinds: []string{"Seeker"}},
+			want:  sorted(seeker1, seeker2),
+		},
+		{
+			name:  "kinds ignore case",
+			graph: g,
+			match: &Match{Kinds: []string{"seeker"}},
+			want:  sorted(seeker1, seeker2),
+		},
+		{
+			name:  "several kinds",
+			graph: g,
+			match: &Match{Kinds: []string{"Seeker", "Tile"}},
+			want:  sorted(seeker1, seeker2, tile),
+		},
+		{
+			name:  "unknown kind",
+			graph: g,
+			match: &Match{Kinds: []string{"Missing"}},
+			want:  []string{},
+		},
+		{
+			name:  "unregistered kind matches by id",
+			graph: g,
+			match: &Match{Kinds: []string{unregistered}},
+			want:  []string{unregistered},
+		},
+		{
+			name:  "renamed kind no longer matches the old name",
+			graph: renamed,
+			match: &Match{Kinds: []string{"Tile"}},
+			want:  []string{},
+		},
+		{
+			name:  "renamed kind matches the new name",
+			graph: renamed,
+			match: &Match{Kinds: []string{"Square"}},
+			want:  []string{tile},
+		},
+		{
+			name:  "ids ignore case and duplicates",
+			graph: g,
+			match: &Match{Ids: []string{strings.ToUpper(tile), tile}},
+			want:  []string{tile},
+		},
+		{
+			name:  "ids and kinds",
+			graph: g,
+			match: &Match{Ids: []string{seeker1, tile}, Kinds: []string{"Tile"}},
+			want:  []string{tile},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sortedNodeIDs(tt.graph.GetNodes(tt.match))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCandidateNodesKindOrder(t *testing.T) {
+	seeker := testNodeID(1, 1)
+	tile := testNodeID(2, 1)
+	g := NewGraph(0)
+	g = testKind(g, 1, "Seeker")
+	g = testKind(g, 2, "Tile")
+	g = g.SetData(seeker, "name", "0x01", 1)
+	g = g.SetData(tile, "name", "0x01", 1)
+
+	// kinds are visited by id whatever order they are asked for in
+	want := []string{seeker, tile}
+	for i := 0; i < 20; i++ {
+		got := g.candidateNodes(&Match{Kinds: []string{"Tile", "Seeker"}})
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("attempt %d: got %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestGetNodesKindsLimit(t *testing.T) {
+	unregistered := testNodeID(0, 1)
+	g := NewGraph(0)
+	g = testKind(g, 1, "Seeker")
+	g = testKind(g, 2, "Tile")
+	seekers := []string{}
+	for key := uint64(1); key <= 5; key++ {
+		seekers = append(seekers, testNodeID(1, key))
+	}
+	tiles := []string{testNodeID(2, 1), testNodeID(2, 2)}
+	for _, id := range append(append([]string{unregistered}, seekers...), tiles...) {
+		g = g.SetData(id, "name", "0x01", 1)
+	}
+
+	two, three := 2, 3
+	tests := []struct {
+		name  string
+		match *Match
+		want  []string
+	}{
+		{
+			name:  "limit keeps the lowest ids",
+			match: &Match{Kinds: []string{"Tile", "Seeker"}, Limit: &three},
+			want:  seekers[:3],
+		},
+		{
+			name:  "limit includes unregistered kinds in id order",
+			match: &Match{Kinds: []string{"Seeker", unregistered}, Limit: &two},
+			want:  []string{unregistered, seekers[0]},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nodeIDs(g.GetNodes(tt.match))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetByName(t *testing.T) {
+	g := testKind(NewGraph(0), 1, "Seeker")
+	g, relID := testRel(g, 1, "Location", WeightKindUint64)
+	renamed, _ := testRel(testKind(g, 1, "Hunter"), 1, "Position", WeightKindUint64)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "kind", got: g.GetKindByName("Seeker"), want: "0x00000001"},
+		{name: "rel", got: g.GetRelByName("Location"), want: relID},
+		{name: "unknown kind", got: g.GetKindByName("Tile"), want: ""},
+		{name: "renamed kind old name", got: renamed.GetKindByName("Seeker"), want: ""},
+		{name: "renamed kind new name", got: renamed.GetKindByName("Hunter"), want: "0x00000001"},
+		{name: "renamed rel old name", got: renamed.GetRelByName("Location"), want: ""},
+		{name: "renamed rel new name", got: renamed.GetRelByName("Position"), want: relID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func edgeDescriptions(edges []*Edge) []string {
+	descs := []string{}
+	for _, e := range edges {
+		descs = append(descs, fmt.Sprintf("%s %s %d", e.Dir, e.Node().ID, e.Key()))
+	}
+	sort.Strings(descs)
+	return descs
+}
+
+func TestEdgeAdjacency(t *testing.T) {
+	a := testNodeID(1, 1)
+	b := testNodeID(1, 2)
+	c := testNodeID(1, 3)
+	g, rel := testRel(NewGraph(0), 1, "Location", WeightKindUint64)
+	linked := g.SetEdge(rel, 0, a, b, nil, 1).SetEdge(rel, 1, a, c, nil, 1)
+	moved := linked.SetEdge(rel, 0, a, c, nil, 2)
+	removed := moved.RemoveEdge(rel, 0, a, 3).RemoveEdge(rel, 1, a, 3)
+
+	tests := []struct {
+		name  string
+		graph *Graph
+		node  string
+		want  []string
+	}{
+		{name: "linked src", graph: linked, node: a, want: []string{"OUT " + b + " 0", "OUT " + c + " 1"}},
+		{name: "linked dst", graph: linked, node: b, want: []string{"IN " + a + " 0"}},
+		{name: "moved src", graph: moved, node: a, want: []string{"OUT " + c + " 0", "OUT " + c + " 1"}},
+		{name: "moved old dst", graph: moved, node: b, want: []string{}},
+		{name: "moved new dst", graph: moved, node: c, want: []string{"IN " + a + " 0", "IN " + a + " 1"}},
+		{name: "removed src", graph: removed, node: a, want: []string{}},
+		{name: "removed dst", graph: removed, node: c, want: []string{}},
+		{name: "earlier version unchanged", graph: linked, node: c, want: []string{"IN " + a + " 1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edges, err := tt.graph.get(tt.node).Edges(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := edgeDescriptions(edges); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// twos returns the two's complement of n as an unsigned 256 bit value, the
+// way a negative int64 weight arrives from the chain
+func twos(n int64) *big.Int {
+	if n >= 0 {
+		return big.NewInt(n)
+	}
+	return new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(n))
+}
+
+func TestEdgeWeight(t *testing.T) {
+	a := testNodeID(1, 1)
+	b := testNodeID(1, 2)
+	large, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	tests := []struct {
+		name   string
+		kind   WeightKind
+		weight *big.Int
+		want   *big.Int
+	}{
+		{name: "unset", kind: WeightKindUint64, weight: nil, want: big.NewInt(0)},
+		{name: "uint64", kind: WeightKindUint64, weight: big.NewInt(5), want: big.NewInt(5)},
+		{name: "uint64 max", kind: WeightKindUint64, weight: maxUint64, want: maxUint64},
+		{name: "int64 positive", kind: WeightKindInt64, weight: big.NewInt(7), want: big.NewInt(7)},
+		{name: "int64 negative", kind: WeightKindInt64, weight: twos(-5), want: big.NewInt(-5)},
+		{name: "int64 min", kind: WeightKindInt64, weight: twos(math.MinInt64), want: big.NewInt(math.MinInt64)},
+		{name: "bytes", kind: WeightKindBytes, weight: large, want: large},
+		{name: "string", kind: WeightKindString, weight: large, want: large},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, rel := testRel(NewGraph(0), 1, "Balance", tt.kind)
+			g = g.SetEdge(rel, 0, a, b, tt.weight, 1)
+			value, err := g.get(a).Value(&Match{Via: []*RelMatch{{Rel: "Balance"}}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value.Cmp(tt.want) != 0 {
+				t.Fatalf("got %v, want %v", value, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeSumSignedWeights(t *testing.T) {
+	a := testNodeID(1, 1)
+	g, rel := testRel(NewGraph(0), 1, "Balance", WeightKindInt64)
+	g = g.SetEdge(rel, 0, a, testNodeID(1, 2), twos(-5), 1)
+	g = g.SetEdge(rel, 1, a, testNodeID(1, 3), twos(3), 1)
+	sum, err := g.get(a).Sum(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum.Cmp(big.NewInt(-2)) != 0 {
+		t.Fatalf("got %v, want -2", sum)
+	}
+}
@@ -47,9 +47,10 @@ type Subscriptions struct {
 func NewSubscriptions() (*Subscriptions, chan interface{}) {
 	notifications := make(chan interface{}, NotificationBuffer)
 	return &Subscriptions{
-		Events:        map[string]map[uuid.UUID]StateEventSubscription{},
-		TxByOwner:     map[string]map[string]map[uuid.UUID]chan *ActionTransaction{},
-		notifications: notifications,
+		Events:         map[string]map[uuid.UUID]StateEventSubscription{},
+		TxByOwner:      map[string]map[string]map[uuid.UUID]chan *ActionTransaction{},
+		SessionByOwner: map[string]map[string]map[uuid.UUID]chan *Session{},
+		notifications:  notifications,
 	}, notifications
 }
 
@@ -60,14 +61,12 @@ func (subs *Subscriptions) Listen(ctx context.Context) {
 			return
 		case notification := <-subs.notifications:
 			switch obj := notification.(type) {
-			case *BlockEvent:
+			case *StateEvent:
 				subs.Lock()
-				for _, subs := range subs.Events {
-					for _, subscriber := range subs {
-						select {
-						case subscriber.Channel <- obj:
-						default:
-						}
+				for _, subscriber := range subs.Events[obj.StateID] {
+					select {
+					case subscriber.Channel <- obj.Event:
+					default:
 					}
 				}
 				subs.Unlock()
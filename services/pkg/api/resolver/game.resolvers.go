@@ -11,11 +11,17 @@ import (
 	"github.com/playmint/ds-node/pkg/api/model"
 )
 
-func (r *gameResolver) State(ctx context.Context, obj *model.Game, block *int, simulated *bool) (*model.State, error) {
+func (r *gameResolver) State(ctx context.Context, obj *model.Game, block *int, simulated *bool, finalized *bool) (*model.State, error) {
 	if obj == nil {
 		return nil, fmt.Errorf("nil game")
 	}
-	return obj.State(block, simulated), nil
+	if simulated != nil && *simulated && finalized != nil && *finalized {
+		return nil, fmt.Errorf("state cannot be both simulated and finalized")
+	}
+	if block != nil && ((simulated != nil && *simulated) || (finalized != nil && *finalized)) {
+		return nil, fmt.Errorf("historical state cannot be simulated or finalized")
+	}
+	return obj.State(block, simulated, finalized), nil
 }
 
 func (r *gameResolver) Subscribers(ctx context.Context, obj *model.Game) (int, error) {
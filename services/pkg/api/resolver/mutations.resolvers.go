@@ -68,6 +68,16 @@ func (r *mutationResolver) Dispatch(ctx context.Context, gameID string, actions
 	} else if signer == nil {
 		return nil, fmt.Errorf("invalid action: failed to extract signer")
 	}
+	// reject actions outside of the session's scopes before simulating, a
+	// session that has not been indexed yet has no known scopes so its
+	// actions are rejected until it has been
+	session := r.Indexer.GetSession(game.RouterAddress, signer.Hex())
+	if session == nil {
+		return nil, fmt.Errorf("invalid action: no session found for signer %v", signer.Hex())
+	}
+	if err := r.Scopes.Check(common.HexToAddress(game.ID), session.Scopes, actions); err != nil {
+		return nil, err
+	}
 	// push it to the pending batch
 	tx, err := r.Sequencer.Enqueue(
 		ctx,
@@ -23,6 +23,10 @@ func (r *queryResolver) Games(ctx context.Context) ([]*model.Game, error) {
 	return r.Indexer.GetGames(), nil
 }
 
+func (r *queryResolver) Store(ctx context.Context, name string, key *string) (interface{}, error) {
+	return r.Indexer.QueryStore(ctx, name, key)
+}
+
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
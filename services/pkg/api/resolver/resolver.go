@@ -5,8 +5,11 @@ package resolver
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/playmint/ds-node/pkg/api/model"
+	"github.com/playmint/ds-node/pkg/datatypes"
 	"github.com/playmint/ds-node/pkg/indexer"
+	"github.com/playmint/ds-node/pkg/scopes"
 	"github.com/playmint/ds-node/pkg/sequencer"
 )
 
@@ -16,4 +19,17 @@ type Resolver struct {
 	Indexer       indexer.Indexer
 	Sequencer     sequencer.Sequencer
 	Subscriptions *model.Subscriptions
+	Scopes        *scopes.Registry
+	DataTypes     *datatypes.Registry
+}
+
+// gameIDForRouter returns the id of the game that uses the router, or the
+// zero address if the router is unknown
+func (r *Resolver) gameIDForRouter(routerAddr common.Address) common.Address {
+	for _, game := range r.Indexer.GetGames() {
+		if game.RouterAddress == routerAddr {
+			return common.HexToAddress(game.ID)
+		}
+	}
+	return common.Address{}
 }
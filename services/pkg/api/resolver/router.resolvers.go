@@ -8,8 +8,10 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/playmint/ds-node/pkg/api/generated"
 	"github.com/playmint/ds-node/pkg/api/model"
+	"github.com/playmint/ds-node/pkg/scopes"
 )
 
 func (r *actionTransactionResolver) Nonce(ctx context.Context, obj *model.ActionTransaction) (int, error) {
@@ -44,6 +46,15 @@ func (r *routerResolver) Transaction(ctx context.Context, obj *model.Router, id
 	)
 }
 
+func (r *sessionResolver) Scope(ctx context.Context, obj *model.Session) (*model.SessionScope, error) {
+	gameID := r.gameIDForRouter(common.HexToAddress(obj.RouterAddress))
+	return &model.SessionScope{
+		FullAccess: obj.Scopes == scopes.FullAccess,
+		Granted:    r.Scopes.Granted(gameID, obj.Scopes),
+		Bits:       hexutil.EncodeUint64(uint64(obj.Scopes)),
+	}, nil
+}
+
 // ActionTransaction returns generated.ActionTransactionResolver implementation.
 func (r *Resolver) ActionTransaction() generated.ActionTransactionResolver {
 	return &actionTransactionResolver{r}
@@ -52,5 +63,9 @@ func (r *Resolver) ActionTransaction() generated.ActionTransactionResolver {
 // Router returns generated.RouterResolver implementation.
 func (r *Resolver) Router() generated.RouterResolver { return &routerResolver{r} }
 
+// Session returns generated.SessionResolver implementation.
+func (r *Resolver) Session() generated.SessionResolver { return &sessionResolver{r} }
+
 type actionTransactionResolver struct{ *Resolver }
 type routerResolver struct{ *Resolver }
+type sessionResolver struct{ *Resolver }
@@ -5,14 +5,46 @@ package resolver
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/playmint/ds-node/pkg/api/generated"
 	"github.com/playmint/ds-node/pkg/api/model"
 )
 
+func (r *nodeDataResolver) Kind(ctx context.Context, obj *model.NodeData) (*model.AttributeKind, error) {
+	return r.DataTypes.Decode(obj).Kind, nil
+}
+
+func (r *nodeDataResolver) Int(ctx context.Context, obj *model.NodeData) (*big.Int, error) {
+	return r.DataTypes.Decode(obj).Int, nil
+}
+
+func (r *nodeDataResolver) Uint(ctx context.Context, obj *model.NodeData) (*big.Int, error) {
+	return r.DataTypes.Decode(obj).Uint, nil
+}
+
+func (r *nodeDataResolver) Bool(ctx context.Context, obj *model.NodeData) (*bool, error) {
+	return r.DataTypes.Decode(obj).Bool, nil
+}
+
+func (r *nodeDataResolver) Address(ctx context.Context, obj *model.NodeData) (*string, error) {
+	return r.DataTypes.Decode(obj).Address, nil
+}
+
+func (r *nodeDataResolver) String(ctx context.Context, obj *model.NodeData) (*string, error) {
+	return r.DataTypes.Decode(obj).String, nil
+}
+
+func (r *nodeDataResolver) Bytes(ctx context.Context, obj *model.NodeData) (*string, error) {
+	return r.DataTypes.Decode(obj).Bytes, nil
+}
+
 func (r *stateResolver) Block(ctx context.Context, obj *model.State) (int, error) {
-	graph := r.Indexer.GetGraph(common.HexToAddress(obj.ID), obj.Block, obj.Simulated)
+	graph, err := r.Indexer.GetGraph(common.HexToAddress(obj.ID), obj.Block, obj.Simulated, obj.Finalized)
+	if err != nil {
+		return 0, err
+	}
 	if graph == nil {
 		graph = model.NewGraph(0)
 	}
@@ -20,22 +52,54 @@ func (r *stateResolver) Block(ctx context.Context, obj *model.State) (int, error
 }
 
 func (r *stateResolver) Nodes(ctx context.Context, obj *model.State, match *model.Match) ([]*model.Node, error) {
-	graph := r.Indexer.GetGraph(common.HexToAddress(obj.ID), obj.Block, obj.Simulated)
+	graph, err := r.Indexer.GetGraph(common.HexToAddress(obj.ID), obj.Block, obj.Simulated, obj.Finalized)
+	if err != nil {
+		return nil, err
+	}
 	if graph == nil {
 		graph = model.NewGraph(0)
 	}
 	return graph.GetNodes(match), nil
 }
 
+func (r *stateResolver) NodesConnection(ctx context.Context, obj *model.State, match *model.Match, first *int, after *string, orderBy *model.NodeOrder) (*model.NodeConnection, error) {
+	graph, err := r.Indexer.GetGraph(common.HexToAddress(obj.ID), obj.Block, obj.Simulated, obj.Finalized)
+	if err != nil {
+		return nil, err
+	}
+	if graph == nil {
+		graph = model.NewGraph(0)
+	}
+	return graph.GetNodesConnection(match, first, after, orderBy)
+}
+
 func (r *stateResolver) Node(ctx context.Context, obj *model.State, match *model.Match) (*model.Node, error) {
-	graph := r.Indexer.GetGraph(common.HexToAddress(obj.ID), obj.Block, obj.Simulated)
+	graph, err := r.Indexer.GetGraph(common.HexToAddress(obj.ID), obj.Block, obj.Simulated, obj.Finalized)
+	if err != nil {
+		return nil, err
+	}
 	if graph == nil {
 		graph = model.NewGraph(0)
 	}
 	return graph.GetNode(match), nil
 }
 
+func (r *stateResolver) Path(ctx context.Context, obj *model.State, from string, to string, match *model.Match, weighted *bool) ([]*model.Edge, error) {
+	graph, err := r.Indexer.GetGraph(common.HexToAddress(obj.ID), obj.Block, obj.Simulated, obj.Finalized)
+	if err != nil {
+		return nil, err
+	}
+	if graph == nil {
+		graph = model.NewGraph(0)
+	}
+	return graph.GetPath(from, to, match, weighted != nil && *weighted)
+}
+
+// NodeData returns generated.NodeDataResolver implementation.
+func (r *Resolver) NodeData() generated.NodeDataResolver { return &nodeDataResolver{r} }
+
 // State returns generated.StateResolver implementation.
 func (r *Resolver) State() generated.StateResolver { return &stateResolver{r} }
 
+type nodeDataResolver struct{ *Resolver }
 type stateResolver struct{ *Resolver }
@@ -113,16 +113,25 @@ func (c *Client) NewRelayTransactor(ctx context.Context) (*bind.TransactOpts, er
 }
 
 // [{"forking": {"jsonRpcUrl": "httKBrdf", "blockNumber": 14000000}}
-func (c *Client) Reset(ctx context.Context, remoteURL string, remoteBlockNumber uint64) (*json.RawMessage, error) {
-	forking := map[string]interface{}{
-		"jsonRpcUrl":  remoteURL,
-		"blockNumber": remoteBlockNumber,
-	}
-	params := map[string]interface{}{
-		"forking": forking,
-	}
+// Reset resets an anvil chain, forking remoteURL at remoteBlockNumber (or its
+// latest block if nil) or to a fresh chain if remoteURL is empty
+func (c *Client) Reset(ctx context.Context, remoteURL string, remoteBlockNumber *uint64) (*json.RawMessage, error) {
 	var res json.RawMessage
-	err := c.rpc.CallContext(ctx, &res, "anvil_reset", params)
+	var err error
+	if remoteURL == "" {
+		err = c.rpc.CallContext(ctx, &res, "anvil_reset")
+	} else {
+		forking := map[string]interface{}{
+			"jsonRpcUrl": remoteURL,
+		}
+		if remoteBlockNumber != nil {
+			forking["blockNumber"] = *remoteBlockNumber
+		}
+		params := map[string]interface{}{
+			"forking": forking,
+		}
+		err = c.rpc.CallContext(ctx, &res, "anvil_reset", params)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -130,8 +139,12 @@ func (c *Client) Reset(ctx context.Context, remoteURL string, remoteBlockNumber
 }
 
 func (c *Client) EnableAutoMine(ctx context.Context) (*json.RawMessage, error) {
+	return c.SetAutoMine(ctx, true)
+}
+
+func (c *Client) SetAutoMine(ctx context.Context, enabled bool) (*json.RawMessage, error) {
 	var res json.RawMessage
-	err := c.rpc.CallContext(ctx, &res, "evm_setAutomine", true)
+	err := c.rpc.CallContext(ctx, &res, "evm_setAutomine", enabled)
 	if err != nil {
 		return nil, err
 	}
@@ -139,8 +152,14 @@ func (c *Client) EnableAutoMine(ctx context.Context) (*json.RawMessage, error) {
 }
 
 func (c *Client) MineEmptyBlock(ctx context.Context) (*json.RawMessage, error) {
+	return c.Mine(ctx, 1)
+}
+
+// Mine mines the given number of blocks on an anvil chain, including any
+// pending transactions
+func (c *Client) Mine(ctx context.Context, blocks uint64) (*json.RawMessage, error) {
 	var res json.RawMessage
-	err := c.rpc.CallContext(ctx, &res, "anvil_mine", 1)
+	err := c.rpc.CallContext(ctx, &res, "anvil_mine", blocks)
 	if err != nil {
 		return nil, err
 	}
@@ -7,3 +7,28 @@ func IsRetryable(err error) bool {
 	msg := err.Error()
 	return strings.Contains(msg, "Too Many Requests") || strings.Contains(msg, "GOAWAY") || strings.Contains(msg, "connection reset")
 }
+
+// rangeTooLargeMessages are the errors various providers return when an
+// eth_getLogs query covers too many blocks or matches too many logs
+var rangeTooLargeMessages = []string{
+	"query returned more than",
+	"block range too large",
+	"block range is too large",
+	"range is too large",
+	"exceed maximum block range",
+	"log response size exceeded",
+	"response size exceeded",
+	"too many results",
+}
+
+// same nastiness as IsRetryable but for checking if the provider rejected a
+// log query because the range was too large and it should be split up
+func IsRangeTooLarge(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range rangeTooLargeMessages {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,10 +2,25 @@ package config
 
 import "github.com/ethereum/go-ethereum/common"
 
-var IndexerProviderHTTP = getRequiredEnvString("INDEXER_PROVIDER_URL_HTTP")
-var IndexerProviderWS = getRequiredEnvString("INDEXER_PROVIDER_URL_WS")
+var IndexerProviderHTTP = getOptionalEnvString("INDEXER_PROVIDER_URL_HTTP", "")
+var IndexerProviderWS = getOptionalEnvString("INDEXER_PROVIDER_URL_WS", "")
+var IndexerPollIntervalMilliseconds = getOptionalEnvInt("INDEXER_POLL_INTERVAL_MS", 1000)
+var IndexerMaxWebsocketFailures = getOptionalEnvInt("INDEXER_WS_MAX_FAILURES", 5)
 var IndexerMaxConcurrency = getOptionalEnvInt("INDEXER_MAX_CONCURRENCY", 200)
 var IndexerMaxLogRange = getOptionalEnvInt("INDEXER_MAX_LOG_RANGE", 1000)
+var IndexerReorgDepth = getOptionalEnvInt("INDEXER_REORG_DEPTH", 64)
+var IndexerStateHistoryBlocks = getOptionalEnvInt("INDEXER_STATE_HISTORY_BLOCKS", 256)
+var IndexerSessionExpiryGraceBlocks = getOptionalEnvInt("INDEXER_SESSION_EXPIRY_GRACE_BLOCKS", 300)
+var IndexerConfirmations = getOptionalEnvInt("INDEXER_CONFIRMATIONS", 12)
+var IndexerFinalizedTag = getOptionalEnvBool("INDEXER_FINALIZED_TAG", "true")
+var IndexerCheckpointPath = getOptionalEnvString("INDEXER_CHECKPOINT_PATH", "")
+var IndexerSnapshotPath = getOptionalEnvString("INDEXER_SNAPSHOT_PATH", "")
+var IndexerSnapshotExport = getOptionalEnvBool("INDEXER_SNAPSHOT_EXPORT", "false")
+var IndexerCheckpointIntervalSeconds = getOptionalEnvInt("INDEXER_CHECKPOINT_INTERVAL_SECONDS", 60)
+var IndexerSubscriberBuffer = getOptionalEnvInt("INDEXER_SUBSCRIBER_BUFFER", 64)
+var IndexerSubscriberOverflow = getOptionalEnvString("INDEXER_SUBSCRIBER_OVERFLOW", "block")
+var IndexerRecordPath = getOptionalEnvString("INDEXER_RECORD_PATH", "")
+var IndexerReplayPath = getOptionalEnvString("INDEXER_REPLAY_PATH", "")
 var IndexerWatchPending = getOptionalEnvBool("INDEXER_WATCH_PENDING", "true")
 var IndexerGameAddress = getOptionalEnvAddress("INDEXER_GAME_ADDRESS", common.Address{})
 var IndexerStateAddress = getOptionalEnvAddress("INDEXER_STATE_ADDRESS", common.Address{})
@@ -16,7 +31,12 @@ var SequencerProviderWS = getRequiredEnvString("SEQUENCER_PROVIDER_URL_WS")
 var SequencerPrivateKey = getRequiredEnvKey("SEQUENCER_PRIVATE_KEY")
 var SequencerMaxConcurrency = getOptionalEnvInt("SEQUENCER_MAX_CONCURRENCY", 200)
 var SequencerMinBatchDelayMilliseconds = getOptionalEnvInt("SEQUENCER_MIN_BATCH_DELAY_MS", 100)
-var SequencerMineEmpty = getOptionalEnvBool("SEQUENCER_MINE_EMPTY", "true")
+var SequencerMineEmpty = getOptionalEnvBool("SEQUENCER_MINE_EMPTY", "false")
 var SequencerPendingSim = getOptionalEnvBool("SEQUENCER_PENDING_SIM", "false")
 
+var DevMode = getOptionalEnvBool("DEV_MODE", "false")
+var DevForkURL = getOptionalEnvString("DEV_FORK_URL", "")
+
 var APIPort = getOptionalEnvInt("API_PORT", 8080)
+var ScopesConfigPath = getOptionalEnvString("SCOPES_CONFIG_PATH", "")
+var NodeDataTypesPath = getOptionalEnvString("NODE_DATA_TYPES_PATH", "")
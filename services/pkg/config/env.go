@@ -18,6 +18,14 @@ func getRequiredEnvString(name string) string {
 	return v
 }
 
+func getOptionalEnvString(name string, defvalue string) string {
+	v := os.Getenv(name)
+	if v == "" {
+		return defvalue
+	}
+	return v
+}
+
 func getOptionalEnvAddress(name string, defvalue common.Address) common.Address {
 	v := os.Getenv(name)
 	if v == "" {
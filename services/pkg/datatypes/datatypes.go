@@ -0,0 +1,180 @@
+package datatypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/playmint/ds-node/pkg/api/model"
+)
+
+// Registry holds the declared type of each node data label, per node kind
+type Registry struct {
+	kinds map[string]map[string]model.AttributeKind
+}
+
+// Definitions is the format of the node data types config file, eg
+//
+//	{"kinds": {"Tile": {"biome": "UINT8", "owner": "ADDRESS"}}}
+type Definitions struct {
+	Kinds map[string]map[string]model.AttributeKind `json:"kinds"` // keyed by kind name then label
+}
+
+// NewRegistry returns a registry of the declared types, array kinds are
+// rejected as they cannot be stored in a node data slot
+func NewRegistry(defs *Definitions) (*Registry, error) {
+	r := &Registry{
+		kinds: map[string]map[string]model.AttributeKind{},
+	}
+	if defs == nil {
+		return r, nil
+	}
+	for kind, labels := range defs.Kinds {
+		for label, attrKind := range labels {
+			if !attrKind.IsValid() {
+				return nil, fmt.Errorf("datatypes: invalid kind %v for %v.%v", attrKind, kind, label)
+			}
+			if !Supported(attrKind) {
+				return nil, fmt.Errorf("datatypes: %v for %v.%v does not fit in 32 bytes", attrKind, kind, label)
+			}
+		}
+		r.kinds[kind] = labels
+	}
+	return r, nil
+}
+
+// Load reads type declarations from a json file, an empty path returns a
+// registry with no declared types
+func Load(path string) (*Registry, error) {
+	if path == "" {
+		return NewRegistry(nil)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var defs Definitions
+	if err := json.Unmarshal(b, &defs); err != nil {
+		return nil, fmt.Errorf("datatypes: failed to decode %v: %v", path, err)
+	}
+	return NewRegistry(&defs)
+}
+
+// Kind returns the declared type of the label for nodes of the given kind,
+// a nil Registry has no declared types
+func (r *Registry) Kind(nodeKind string, label string) (model.AttributeKind, bool) {
+	if r == nil {
+		return "", false
+	}
+	attrKind, ok := r.kinds[nodeKind][label]
+	return attrKind, ok
+}
+
+// Value is node data decoded according to its declared type, only the field
+// matching the type is set
+type Value struct {
+	Kind    *model.AttributeKind
+	Int     *big.Int
+	Uint    *big.Int
+	Bool    *bool
+	Address *string
+	String  *string
+	Bytes   *string
+}
+
+// Decode returns the node data decoded according to the type declared for
+// its label, the Value is empty if no type is declared or the raw value is
+// not 32 bytes
+func (r *Registry) Decode(data *model.NodeData) *Value {
+	attrKind, ok := r.Kind(data.NodeKind, data.Name)
+	if !ok {
+		return &Value{}
+	}
+	raw, err := hexutil.Decode(data.Value)
+	if err != nil || len(raw) != 32 {
+		return &Value{}
+	}
+	v := decode(attrKind, raw)
+	v.Kind = &attrKind
+	return v
+}
+
+// Supported reports whether values of the kind can be stored in the 32 byte
+// node data slots, array kinds cannot
+func Supported(attrKind model.AttributeKind) bool {
+	return !strings.HasSuffix(string(attrKind), "_ARRAY")
+}
+
+// decode interprets a bytes32 the way solidity converts each type to one.
+// Numbers, bools and addresses are right aligned, strings and bytes are left
+// aligned. Signed and sized numbers only use their low bits so that both sign
+// and zero extended values decode the same.
+func decode(attrKind model.AttributeKind, raw []byte) *Value {
+	v := &Value{}
+	switch attrKind {
+	case model.AttributeKindBool:
+		b := new(big.Int).SetBytes(raw).Sign() != 0
+		v.Bool = &b
+	case model.AttributeKindAddress:
+		addr := common.BytesToAddress(raw).Hex()
+		v.Address = &addr
+	case model.AttributeKindString:
+		s := strings.TrimRight(string(raw), "\x00")
+		v.String = &s
+	case model.AttributeKindBytes:
+		b := hexutil.Encode(raw)
+		v.Bytes = &b
+	case model.AttributeKindBytes4:
+		b := hexutil.Encode(raw[:4])
+		v.Bytes = &b
+	default:
+		if bits, signed, ok := intSize(attrKind); ok {
+			n := new(big.Int).SetBytes(raw)
+			n.And(n, new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), bits), big.NewInt(1)))
+			if signed {
+				if n.Bit(int(bits)-1) == 1 {
+					n.Sub(n, new(big.Int).Lsh(big.NewInt(1), bits))
+				}
+				v.Int = n
+			} else {
+				v.Uint = n
+			}
+		}
+	}
+	return v
+}
+
+// intSize returns the number of bits in a numeric kind
+func intSize(attrKind model.AttributeKind) (bits uint, signed bool, ok bool) {
+	switch attrKind {
+	case model.AttributeKindInt8:
+		return 8, true, true
+	case model.AttributeKindInt16:
+		return 16, true, true
+	case model.AttributeKindInt32:
+		return 32, true, true
+	case model.AttributeKindInt64:
+		return 64, true, true
+	case model.AttributeKindInt128:
+		return 128, true, true
+	case model.AttributeKindInt256, model.AttributeKindInt:
+		return 256, true, true
+	case model.AttributeKindUINt8:
+		return 8, false, true
+	case model.AttributeKindUINt16:
+		return 16, false, true
+	case model.AttributeKindUINt32:
+		return 32, false, true
+	case model.AttributeKindUINt64:
+		return 64, false, true
+	case model.AttributeKindUINt128:
+		return 128, false, true
+	case model.AttributeKindUINt256:
+		return 256, false, true
+	}
+	return 0, false, false
+}
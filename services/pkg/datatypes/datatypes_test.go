@@ -0,0 +1,166 @@
+package datatypes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/playmint/ds-node/pkg/api/model"
+)
+
+// right aligns b in a bytes32, the way numbers are stored
+func right(b ...byte) string {
+	return hexutil.Encode(common.LeftPadBytes(b, 32))
+}
+
+// left aligns b in a bytes32, the way strings and bytes are stored
+func left(b ...byte) string {
+	return hexutil.Encode(common.RightPadBytes(b, 32))
+}
+
+// ones is a bytes32 with every bit set, a sign extended -1
+func ones() string {
+	b := make([]byte, 32)
+	for i := range b {
+		b[i] = 0xff
+	}
+	return hexutil.Encode(b)
+}
+
+// describe returns the field of the value that is set
+func describe(v *Value) string {
+	switch {
+	case v.Int != nil:
+		return fmt.Sprintf("int %v", v.Int)
+	case v.Uint != nil:
+		return fmt.Sprintf("uint %v", v.Uint)
+	case v.Bool != nil:
+		return fmt.Sprintf("bool %v", *v.Bool)
+	case v.Address != nil:
+		return fmt.Sprintf("address %v", *v.Address)
+	case v.String != nil:
+		return fmt.Sprintf("string %q", *v.String)
+	case v.Bytes != nil:
+		return fmt.Sprintf("bytes %v", *v.Bytes)
+	}
+	return "empty"
+}
+
+func TestDecode(t *testing.T) {
+	addr := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	tests := []struct {
+		name  string
+		kind  model.AttributeKind
+		value string
+		want  string
+	}{
+		{name: "uint8", kind: model.AttributeKindUINt8, value: right(0xff), want: "uint 255"},
+		{name: "uint8 ignores high bits", kind: model.AttributeKindUINt8, value: right(0x01, 0x02), want: "uint 2"},
+		{name: "uint256", kind: model.AttributeKindUINt256, value: ones(), want: "uint 115792089237316195423570985008687907853269984665640564039457584007913129639935"},
+		{name: "int8 zero extended", kind: model.AttributeKindInt8, value: right(0xff), want: "int -1"},
+		{name: "int8 sign extended", kind: model.AttributeKindInt8, value: ones(), want: "int -1"},
+		{name: "int8 positive", kind: model.AttributeKindInt8, value: right(0x7f), want: "int 127"},
+		{name: "int64 min", kind: model.AttributeKindInt64, value: right(0x80, 0, 0, 0, 0, 0, 0, 0), want: "int -9223372036854775808"},
+		{name: "int", kind: model.AttributeKindInt, value: ones(), want: "int -1"},
+		{name: "bool true", kind: model.AttributeKindBool, value: right(0x01), want: "bool true"},
+		{name: "bool false", kind: model.AttributeKindBool, value: right(), want: "bool false"},
+		{name: "address", kind: model.AttributeKindAddress, value: right(addr.Bytes()...), want: "address " + addr.Hex()},
+		{name: "string", kind: model.AttributeKindString, value: left([]byte("corn")...), want: `string "corn"`},
+		{name: "bytes4", kind: model.AttributeKindBytes4, value: left(0xde, 0xad, 0xbe, 0xef), want: "bytes 0xdeadbeef"},
+		{name: "bytes", kind: model.AttributeKindBytes, value: left(0xde, 0xad), want: "bytes " + left(0xde, 0xad)},
+		{name: "not 32 bytes", kind: model.AttributeKindUINt8, value: "0x01", want: "empty"},
+		{name: "not hex", kind: model.AttributeKindUINt8, value: "corn", want: "empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRegistry(&Definitions{
+				Kinds: map[string]map[string]model.AttributeKind{
+					"Tile": {"label": tt.kind},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			v := r.Decode(&model.NodeData{Name: "label", Value: tt.value, NodeKind: "Tile"})
+			if got := describe(v); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			if tt.want != "empty" && (v.Kind == nil || *v.Kind != tt.kind) {
+				t.Fatalf("got kind %v, want %v", v.Kind, tt.kind)
+			}
+		})
+	}
+}
+
+func TestDecodeUndeclared(t *testing.T) {
+	r, err := NewRegistry(&Definitions{
+		Kinds: map[string]map[string]model.AttributeKind{
+			"Tile": {"biome": model.AttributeKindUINt8},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var nilRegistry *Registry
+	tests := []struct {
+		name     string
+		registry *Registry
+		data     *model.NodeData
+	}{
+		{name: "other label", registry: r, data: &model.NodeData{Name: "owner", Value: right(1), NodeKind: "Tile"}},
+		{name: "other kind", registry: r, data: &model.NodeData{Name: "biome", Value: right(1), NodeKind: "Seeker"}},
+		{name: "nil registry", registry: nilRegistry, data: &model.NodeData{Name: "biome", Value: right(1), NodeKind: "Tile"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.registry.Decode(tt.data)
+			if got := describe(v); got != "empty" || v.Kind != nil {
+				t.Fatalf("got %v, want empty", got)
+			}
+		})
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    model.AttributeKind
+		wantErr bool
+	}{
+		{name: "supported", kind: model.AttributeKindAddress},
+		{name: "array", kind: model.AttributeKindUINt8Array, wantErr: true},
+		{name: "invalid", kind: model.AttributeKind("FLOAT"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRegistry(&Definitions{
+				Kinds: map[string]map[string]model.AttributeKind{
+					"Tile": {"label": tt.kind},
+				},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "datatypes.json")
+	if err := os.WriteFile(path, []byte(`{"kinds": {"Tile": {"biome": "UINT8"}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind, ok := r.Kind("Tile", "biome"); !ok || kind != model.AttributeKindUINt8 {
+		t.Fatalf("got %v %v, want UINT8", kind, ok)
+	}
+	if r, err := Load(""); err != nil || r == nil {
+		t.Fatalf("got %v %v, want an empty registry", r, err)
+	}
+}
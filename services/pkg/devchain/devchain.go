@@ -0,0 +1,75 @@
+package devchain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/playmint/ds-node/pkg/client/alchemy"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Resyncer is implemented by indexers that can start again from the first
+// block after the chain has been reset
+type Resyncer interface {
+	Resync() error
+}
+
+// Controller drives a local anvil chain for scripting test scenarios. It
+// must only be enabled in dev mode as the calls fail against any other chain.
+type Controller struct {
+	client  *alchemy.Client
+	forkURL string
+	indexer Resyncer
+	log     zerolog.Logger
+}
+
+// New returns a Controller for the chain behind client. forkURL is the only
+// chain Reset forks from, empty resets to a fresh chain.
+func New(client *alchemy.Client, forkURL string, indexer Resyncer) *Controller {
+	return &Controller{
+		client:  client,
+		forkURL: forkURL,
+		indexer: indexer,
+		log:     log.With().Str("service", "devchain").Logger(),
+	}
+}
+
+// Mine mines the given number of blocks, including any pending transactions
+func (c *Controller) Mine(ctx context.Context, blocks uint64) error {
+	if blocks < 1 {
+		return fmt.Errorf("must mine at least one block")
+	}
+	if _, err := c.client.Mine(ctx, blocks); err != nil {
+		return err
+	}
+	c.log.Info().Uint64("blocks", blocks).Msg("mined")
+	return nil
+}
+
+// SetAutoMine toggles mining a block for every transaction
+func (c *Controller) SetAutoMine(ctx context.Context, enabled bool) error {
+	if _, err := c.client.SetAutoMine(ctx, enabled); err != nil {
+		return err
+	}
+	c.log.Info().Bool("enabled", enabled).Msg("automine")
+	return nil
+}
+
+// Reset replaces the chain with a fork of the fork url given to New at
+// block, or its latest block if nil, or a fresh chain if there is no fork url,
+// and resyncs the indexer
+func (c *Controller) Reset(ctx context.Context, block *uint64) error {
+	if c.forkURL == "" && block != nil {
+		return fmt.Errorf("resetting to a block requires a fork url")
+	}
+	if _, err := c.client.Reset(ctx, c.forkURL, block); err != nil {
+		return err
+	}
+	event := c.log.Warn().Str("fork", c.forkURL)
+	if block != nil {
+		event = event.Uint64("block", *block)
+	}
+	event.Msg("reset")
+	return c.indexer.Resync()
+}
@@ -0,0 +1,97 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/playmint/ds-node/pkg/api/model"
+)
+
+// Version is bumped whenever the checkpoint format changes, checkpoints
+// written with a different version are ignored and the index is rebuilt
+const Version = 3
+
+// Checkpoint is the contents of all the indexer stores as of Block
+type Checkpoint struct {
+	Version      int                             `json:"version"`
+	ChainID      uint64                          `json:"chainId"`
+	Block        int64                           `json:"block"`
+	Graphs       map[string]*model.GraphSnapshot `json:"graphs"` // keyed by state contract address
+	Games        []*model.Game                   `json:"games"`
+	Latest       string                          `json:"latest"`
+	LatestByName map[string]string               `json:"latestByName"`
+	Sessions     []*model.Session                `json:"sessions"`
+	Stores       map[string]json.RawMessage      `json:"stores"` // custom stores keyed by registered name
+}
+
+// Backend persists checkpoints. Load returns nil without error when there is
+// no checkpoint to restore from.
+type Backend interface {
+	Load() (*Checkpoint, error)
+	Save(cp *Checkpoint) error
+	Clear() error
+}
+
+var _ Backend = &FileBackend{}
+
+// FileBackend keeps the most recent checkpoint as a single json file
+type FileBackend struct {
+	path string
+}
+
+func NewFileBackend(path string) *FileBackend {
+	return &FileBackend{
+		path: path,
+	}
+}
+
+func (b *FileBackend) Load() (*Checkpoint, error) {
+	f, err := os.Open(b.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var cp Checkpoint
+	if err := json.NewDecoder(f).Decode(&cp); err != nil {
+		return nil, fmt.Errorf("checkpoint: failed to decode %v: %v", b.path, err)
+	}
+	if cp.Version != Version {
+		return nil, nil
+	}
+	return &cp, nil
+}
+
+// Save writes to a temporary file and renames it over the previous
+// checkpoint so that a crash mid-write never leaves a corrupt checkpoint
+func (b *FileBackend) Save(cp *Checkpoint) error {
+	cp.Version = Version
+	f, err := os.CreateTemp(filepath.Dir(b.path), filepath.Base(b.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := json.NewEncoder(f).Encode(cp); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), b.path)
+}
+
+// Clear removes the checkpoint so that nothing is restored from it
+func (b *FileBackend) Clear() error {
+	if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// SnapshotFormat identifies files written by WriteSnapshot
+const SnapshotFormat = "ds-node-snapshot"
+
+// Snapshot is a checkpoint exported for debugging or for seeding a new node.
+// It is a checkpoint with a format marker so that it can be told apart from
+// other json files, and unlike Load a version mismatch is an error.
+type Snapshot struct {
+	Format string `json:"format"`
+	*Checkpoint
+}
+
+// WriteSnapshot encodes cp as a snapshot
+func WriteSnapshot(w io.Writer, cp *Checkpoint) error {
+	cp.Version = Version
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&Snapshot{
+		Format:     SnapshotFormat,
+		Checkpoint: cp,
+	})
+}
+
+// ReadSnapshot decodes a snapshot written by WriteSnapshot
+func ReadSnapshot(r io.Reader) (*Checkpoint, error) {
+	var s Snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("snapshot: failed to decode: %v", err)
+	}
+	if s.Format != SnapshotFormat {
+		return nil, fmt.Errorf("snapshot: unknown format %q", s.Format)
+	}
+	if s.Checkpoint == nil || s.Version != Version {
+		return nil, fmt.Errorf("snapshot: unsupported version, expected %d", Version)
+	}
+	return s.Checkpoint, nil
+}
+
+// LoadSnapshot reads a snapshot from a file
+func LoadSnapshot(path string) (*Checkpoint, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadSnapshot(f)
+}
@@ -0,0 +1,210 @@
+package eventwatcher
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Discoverer finds further contracts to watch in logs matching Topics, for
+// example a game deployment announcing its state and router contracts
+type Discoverer struct {
+	Topics   []common.Hash
+	Discover func(log types.Log) []common.Address
+}
+
+// AddDiscoverer registers a Discoverer, it must be called before Start.
+// Discoverers are only used when watching a fixed set of addresses.
+func (rs *Watcher) AddDiscoverer(d Discoverer) {
+	rs.discoverers = append(rs.discoverers, d)
+}
+
+// WatchAddresses adds contracts to the set being watched, it must be called
+// before Start. It does nothing if the watcher is watching every contract.
+func (rs *Watcher) WatchAddresses(addrs ...common.Address) {
+	rs.addAddresses(addrs)
+}
+
+// addAddresses adds to the watched set and returns those that were not
+// already in it
+func (rs *Watcher) addAddresses(addrs []common.Address) []common.Address {
+	rs.addressesLock.Lock()
+	defer rs.addressesLock.Unlock()
+	added := []common.Address{}
+	if rs.addresses == nil {
+		return added
+	}
+	for _, addr := range addrs {
+		if rs.addresses[addr] {
+			continue
+		}
+		rs.addresses[addr] = true
+		added = append(added, addr)
+		rs.log.Info().Str("address", addr.Hex()).Msg("watch-address")
+	}
+	return added
+}
+
+// watchedAddresses returns the current set of watched contracts, or nil if
+// every contract is being watched
+func (rs *Watcher) watchedAddresses() []common.Address {
+	rs.addressesLock.RLock()
+	defer rs.addressesLock.RUnlock()
+	if rs.addresses == nil {
+		return nil
+	}
+	addrs := make([]common.Address, 0, len(rs.addresses))
+	for addr := range rs.addresses {
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+func (rs *Watcher) filtered() bool {
+	rs.addressesLock.RLock()
+	defer rs.addressesLock.RUnlock()
+	return rs.addresses != nil
+}
+
+// resetAddresses forgets every contract added since the watcher was
+// created, leaving only the configured set
+func (rs *Watcher) resetAddresses() {
+	rs.addressesLock.Lock()
+	defer rs.addressesLock.Unlock()
+	if rs.addresses == nil {
+		return
+	}
+	rs.addresses = map[common.Address]bool{}
+	for _, addr := range rs.config.Addresses {
+		rs.addresses[addr] = true
+	}
+}
+
+// discover runs the discoverers over the batch, it returns the earliest
+// deployment block of any newly discovered contracts
+func (rs *Watcher) discover(ctx context.Context, batch *LogBatch) (int64, bool) {
+	if len(rs.discoverers) == 0 || !rs.filtered() {
+		return 0, false
+	}
+	from, ok := int64(0), false
+	for _, log := range batch.Logs {
+		if log.Removed {
+			continue
+		}
+		for _, addr := range rs.addAddresses(rs.discovered(log)) {
+			block := rs.deploymentBlock(ctx, addr, int64(log.BlockNumber))
+			if !ok || block < from {
+				from, ok = block, true
+			}
+		}
+	}
+	return from, ok
+}
+
+func (rs *Watcher) discovered(log types.Log) []common.Address {
+	addrs := []common.Address{}
+	if len(log.Topics) == 0 {
+		return addrs
+	}
+	for _, d := range rs.discoverers {
+		for _, topic := range d.Topics {
+			if log.Topics[0] == topic {
+				addrs = append(addrs, d.Discover(log)...)
+			}
+		}
+	}
+	return addrs
+}
+
+// discoverAll searches every block up to toBlock for contracts to watch
+// before the main catch up, so that their logs are fetched alongside
+// everything else rather than needing to be backfilled
+func (rs *Watcher) discoverAll(ctx context.Context, toBlock int64) {
+	if len(rs.discoverers) == 0 || !rs.filtered() {
+		return
+	}
+	topics := []common.Hash{}
+	for _, d := range rs.discoverers {
+		topics = append(topics, d.Topics...)
+	}
+	// search the currently watched contracts, then any that they
+	// announce, until there are no new contracts found. contracts cannot
+	// emit logs before they are deployed, so each round only searches from
+	// the earliest deployment of the contracts found by the previous one.
+	addrs := rs.watchedAddresses()
+	from := rs.config.EpochBlock
+	for len(addrs) > 0 && from <= toBlock {
+		query := ethereum.FilterQuery{
+			Topics:    [][]common.Hash{topics},
+			Addresses: addrs,
+		}
+		added := []common.Address{}
+		next := toBlock + 1
+		for logs := range rs.fetchRange(ctx, from, toBlock, query) {
+			for _, log := range logs.Logs {
+				for _, addr := range rs.addAddresses(rs.discovered(log)) {
+					added = append(added, addr)
+					next = min(next, rs.deploymentBlock(ctx, addr, int64(log.BlockNumber)))
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			return // cancelled
+		}
+		addrs = added
+		if next > rs.config.EpochBlock {
+			from = next
+		}
+	}
+}
+
+// deploymentBlock searches for the block the contract was deployed in, given
+// that it exists at block hi. The search is limited to the blocks the stores
+// are able to rewind, contracts deployed before that are only backfilled from
+// the start of that window. If the provider cannot answer for a block (such
+// as a pruned node asked about old state) EpochBlock is returned so that
+// nothing is missed.
+func (rs *Watcher) deploymentBlock(ctx context.Context, addr common.Address, hi int64) int64 {
+	lo := rs.lastBlock - int64(rs.reorgDepth()) + 1
+	if lo < rs.config.EpochBlock {
+		lo = rs.config.EpochBlock
+	}
+	if hi < lo {
+		return hi
+	}
+	hasCode := func(block int64) (bool, error) {
+		code, err := rs.config.HTTPClient.CodeAt(ctx, addr, big.NewInt(block))
+		if err != nil {
+			rs.log.Warn().Err(err).Str("address", addr.Hex()).Int64("block", block).Msg("deployment-block-search-fail")
+		}
+		return len(code) > 0, err
+	}
+	if ok, err := hasCode(hi); err != nil {
+		return rs.config.EpochBlock
+	} else if !ok {
+		return hi
+	}
+	if ok, err := hasCode(lo); err == nil && ok {
+		rs.log.Warn().
+			Str("address", addr.Hex()).
+			Int64("from", lo).
+			Msg("backfill-truncated")
+		return lo
+	}
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		ok, err := hasCode(mid)
+		if err != nil {
+			return rs.config.EpochBlock
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return hi
+}
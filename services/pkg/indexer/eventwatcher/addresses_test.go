@@ -0,0 +1,87 @@
+package eventwatcher
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// codeChain is a testChain with a contract deployed at block deployed, it
+// fails requests for the code at any block before pruned
+type codeChain struct {
+	testChain
+	deployed int64
+	pruned   int64
+}
+
+func (c codeChain) GetCode(ctx context.Context, addr common.Address, block string) (hexutil.Bytes, error) {
+	number, err := hexutil.DecodeUint64(block)
+	if err != nil {
+		return nil, err
+	}
+	if int64(number) < c.pruned {
+		return nil, fmt.Errorf("missing trie node")
+	}
+	if int64(number) < c.deployed {
+		return hexutil.Bytes{}, nil
+	}
+	return hexutil.Bytes{0x60}, nil
+}
+
+func TestDeploymentBlock(t *testing.T) {
+	tests := []struct {
+		name      string
+		epoch     int64
+		lastBlock int64
+		pruned    int64
+		hi        int64
+		want      int64
+	}{
+		{name: "before the initial catch up", lastBlock: 0, hi: 100, want: 50},
+		{name: "within the window", lastBlock: 100, hi: 100, want: 50},
+		{name: "deployed before the window", lastBlock: 200, hi: 200, want: 137},
+		{name: "deployed before the epoch", epoch: 60, lastBlock: 0, hi: 100, want: 60},
+		{name: "window after the block", lastBlock: 200, hi: 80, want: 80},
+		{name: "not deployed yet", lastBlock: 0, hi: 40, want: 40},
+		{name: "pruned state", epoch: 5, lastBlock: 0, pruned: 30, hi: 100, want: 5},
+		{name: "pruned head", epoch: 5, lastBlock: 0, pruned: 200, hi: 100, want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := newTestWatcher(t, codeChain{deployed: 50, pruned: tt.pruned}, Config{
+				EpochBlock: tt.epoch,
+				LogRange:   10,
+				ReorgDepth: 64,
+			})
+			rs.lastBlock = tt.lastBlock
+			got := rs.deploymentBlock(context.Background(), common.HexToAddress("0x01"), tt.hi)
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResyncForgetsDiscoveredAddresses(t *testing.T) {
+	configured := common.HexToAddress("0x01")
+	discovered := common.HexToAddress("0x02")
+	rs := newTestWatcher(t, headChain{head: 3, failures: &atomic.Int32{}}, Config{
+		Addresses: []common.Address{configured},
+		LogRange:  10,
+	})
+	rs.WatchAddresses(discovered)
+	if got := len(rs.watchedAddresses()); got != 2 {
+		t.Fatalf("watching %d addresses before the resync, want 2", got)
+	}
+	if err := rs.resyncHead(context.Background(), ethereum.FilterQuery{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := rs.watchedAddresses(); len(got) != 1 || got[0] != configured {
+		t.Errorf("watching %v after the resync, want [%v]", got, configured)
+	}
+}
@@ -0,0 +1,43 @@
+package eventwatcher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var logRangeGauge = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "indexer_log_range_blocks",
+		Help: "The number of blocks currently requested per eth_getLogs query.",
+	},
+)
+
+var subscriberQueueGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "indexer_subscriber_queue_batches",
+		Help: "The number of batches waiting to be processed by each subscriber.",
+	},
+	[]string{"subscriber"},
+)
+
+var subscriberOverflowCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "indexer_subscriber_overflows_total",
+		Help: "The number of batches published while a subscriber's queue was full.",
+	},
+	[]string{"subscriber", "policy"},
+)
+
+var subscriberBlockedSeconds = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "indexer_subscriber_blocked_seconds_total",
+		Help: "The time the publisher has spent waiting for each subscriber to make room.",
+	},
+	[]string{"subscriber"},
+)
+
+func init() {
+	prometheus.MustRegister(logRangeGauge)
+	prometheus.MustRegister(subscriberQueueGauge)
+	prometheus.MustRegister(subscriberOverflowCounter)
+	prometheus.MustRegister(subscriberBlockedSeconds)
+}
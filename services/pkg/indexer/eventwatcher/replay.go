@@ -0,0 +1,156 @@
+package eventwatcher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// maximum size of a single line in a replay stream
+const maxRecordSize = 64 * 1024 * 1024
+
+// record is a single line of a recording, one per published batch
+type record struct {
+	FromBlock int64       `json:"fromBlock"`
+	ToBlock   int64       `json:"toBlock"`
+	Reorg     bool        `json:"reorg,omitempty"`
+	Reset     bool        `json:"reset,omitempty"`
+	Finalized int64       `json:"finalized"`
+	Logs      []types.Log `json:"logs"`
+}
+
+// record writes the batch to the Recorder, if there is one
+func (rs *Watcher) record(logs *LogBatch) {
+	if rs.config.Recorder == nil {
+		return
+	}
+	b, err := json.Marshal(&record{
+		FromBlock: logs.FromBlock,
+		ToBlock:   logs.ToBlock,
+		Reorg:     logs.Reorg,
+		Reset:     logs.Reset,
+		Finalized: logs.Finalized,
+		Logs:      logs.Logs,
+	})
+	if err == nil {
+		_, err = rs.config.Recorder.Write(append(b, '\n'))
+	}
+	if err != nil {
+		rs.log.Error().
+			Err(err).
+			Int64("from", logs.FromBlock).
+			Int64("to", logs.ToBlock).
+			Msg("record-fail")
+	}
+}
+
+// replay publishes the batches from the Replay stream instead of fetching
+// them from the chain. The stream is JSONL, each line is either a batch as
+// written by the Recorder or a single types.Log, consecutive logs for the same
+// block are published together. Logs are filtered by the subscribed topics
+// but not by address. The watcher is ready once the stream is exhausted, if
+// the stream cannot be read to the end it returns why and is never ready.
+func (rs *Watcher) replay(ctx context.Context) error {
+	if closer, ok := rs.config.Replay.(io.Closer); ok {
+		defer closer.Close()
+	}
+	topics := map[common.Hash]bool{}
+	for _, topic := range rs.topic0 {
+		topics[topic] = true
+	}
+	var pending *LogBatch
+	publish := func(logs *LogBatch) bool {
+		if logs == nil || logs.ToBlock < rs.config.EpochBlock {
+			return true
+		}
+		filtered := []types.Log{}
+		for _, log := range logs.Logs {
+			if int64(log.BlockNumber) < rs.config.EpochBlock || len(log.Topics) == 0 || !topics[log.Topics[0]] {
+				continue
+			}
+			filtered = append(filtered, log)
+		}
+		logs.Logs = filtered
+		if logs.FromBlock < rs.config.EpochBlock {
+			logs.FromBlock = rs.config.EpochBlock
+		}
+		select {
+		case rs.sink <- logs:
+			rs.lastBlock = logs.ToBlock
+			rs.readyBlock = logs.ToBlock
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	scanner := bufio.NewScanner(rs.config.Replay)
+	scanner.Buffer(nil, maxRecordSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		batch, log, err := decodeRecord(scanner.Bytes())
+		if err != nil {
+			return fmt.Errorf("replay: line %d: %v", line, err)
+		}
+		if log != nil && pending != nil && int64(log.BlockNumber) == pending.ToBlock {
+			pending.Logs = append(pending.Logs, *log)
+			continue
+		}
+		if !publish(pending) {
+			return nil // cancelled
+		}
+		pending = batch
+		if log != nil {
+			block := int64(log.BlockNumber)
+			pending = &LogBatch{
+				EventBatch: EventBatch{FromBlock: block, ToBlock: block},
+				Logs:       []types.Log{*log},
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("replay: %v", err)
+	}
+	if !publish(pending) {
+		return nil // cancelled
+	}
+	rs.log.Info().Int64("block", rs.lastBlock).Msg("replay-done")
+	close(rs.ready)
+	return nil
+}
+
+// decodeRecord decodes a line of a replay stream, returning either a batch
+// or a single log
+func decodeRecord(b []byte) (*LogBatch, *types.Log, error) {
+	var probe struct {
+		Logs json.RawMessage `json:"logs"`
+	}
+	if err := json.Unmarshal(b, &probe); err != nil {
+		return nil, nil, err
+	}
+	if probe.Logs == nil {
+		var log types.Log
+		if err := json.Unmarshal(b, &log); err != nil {
+			return nil, nil, fmt.Errorf("invalid log: %v", err)
+		}
+		return nil, &log, nil
+	}
+	var r record
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, nil, fmt.Errorf("invalid batch: %v", err)
+	}
+	return &LogBatch{
+		EventBatch: EventBatch{FromBlock: r.FromBlock, ToBlock: r.ToBlock},
+		Logs:       r.Logs,
+		Reorg:      r.Reorg,
+		Reset:      r.Reset,
+		Finalized:  r.Finalized,
+	}, nil, nil
+}
@@ -0,0 +1,150 @@
+package eventwatcher
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestRecordRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		batch *LogBatch
+	}{
+		{
+			name:  "extends",
+			batch: &LogBatch{EventBatch: EventBatch{FromBlock: 3, ToBlock: 4}, Finalized: 2},
+		},
+		{
+			name:  "reorg",
+			batch: &LogBatch{EventBatch: EventBatch{FromBlock: 3, ToBlock: 4}, Reorg: true, Finalized: 2},
+		},
+		{
+			name:  "reset",
+			batch: &LogBatch{EventBatch: EventBatch{FromBlock: 0, ToBlock: 4}, Reset: true, Finalized: -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.batch.Logs = testBatch(tt.batch.ToBlock).Logs
+			var buf bytes.Buffer
+			rs, err := New(Config{LogRange: 1, Recorder: &buf})
+			if err != nil {
+				t.Fatal(err)
+			}
+			rs.record(tt.batch)
+			got, log, err := decodeRecord(bytes.TrimSpace(buf.Bytes()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if log != nil {
+				t.Fatalf("decoded a log, want a batch")
+			}
+			if !reflect.DeepEqual(got, tt.batch) {
+				t.Fatalf("got %+v, want %+v", got, tt.batch)
+			}
+		})
+	}
+}
+
+// describeBatch summarises the batch as "from-to flags [block:index ...]"
+func describeBatch(logs *LogBatch) string {
+	flags := ""
+	if logs.Reorg {
+		flags += " reorg"
+	}
+	if logs.Reset {
+		flags += " reset"
+	}
+	ids := []string{}
+	for _, log := range logs.Logs {
+		ids = append(ids, fmt.Sprintf("%d:%d", log.BlockNumber, log.Index))
+	}
+	return fmt.Sprintf("%d-%d%s %v", logs.FromBlock, logs.ToBlock, flags, ids)
+}
+
+// replayAll replays the stream and returns every batch published before
+// the watcher became ready or failed, and why it failed
+func replayAll(t *testing.T, cfg Config) (*Watcher, []string, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg.LogRange = 1
+	rs, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := rs.SubscribeTopic("replay", []common.Hash{testTopic})
+	rs.Start(ctx)
+	var failed error
+	select {
+	case <-rs.Ready():
+	case failed = <-rs.Failed():
+	case <-time.After(5 * time.Second):
+		t.Fatal("replay never became ready")
+	}
+	got := []string{}
+	for {
+		select {
+		case logs := <-sub.C():
+			got = append(got, describeBatch(logs))
+		case <-time.After(100 * time.Millisecond):
+			return rs, got, failed
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	f, err := os.Open("testdata/replay.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs, got, err := replayAll(t, Config{Replay: f, EpochBlock: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// block 1 is before the epoch, logs for other topics are dropped and
+	// consecutive logs for the same block are published together
+	want := []string{
+		"2-2 [2:0 2:2]",
+		"3-3 [3:0]",
+		"3-4 reorg [3:1 4:0]",
+		"2-5 reset [5:1]",
+		"6-6 [6:0]",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if rs.ReadyBlock() != 6 {
+		t.Fatalf("got ready block %d, want 6", rs.ReadyBlock())
+	}
+}
+
+func TestReplayInvalid(t *testing.T) {
+	stream := strings.Join([]string{
+		`{"fromBlock":1,"toBlock":1,"finalized":-1,"logs":[]}`,
+		`{"fromBlock":2,"toBlock":2,"finalized":-1,"logs":[]}`,
+		`not json`,
+		`{"fromBlock":3,"toBlock":3,"finalized":-1,"logs":[]}`,
+	}, "\n")
+	rs, got, err := replayAll(t, Config{Replay: strings.NewReader(stream)})
+	// the watcher gives up at the invalid line and never becomes ready
+	if err == nil {
+		t.Fatal("replay did not fail")
+	}
+	want := []string{"1-1 []"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	select {
+	case <-rs.Ready():
+		t.Fatal("ready after failing")
+	default:
+	}
+}
@@ -0,0 +1,274 @@
+package eventwatcher
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// OverflowPolicy decides what the publisher does when a subscriber's queue
+// is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for the subscriber to make room, stalling every
+	// other subscriber until it does, and logs a warning
+	OverflowBlock OverflowPolicy = iota
+	// OverflowResync stops publishing to the subscriber and fetches the
+	// blocks it missed again, queueing them as it makes room, so the other
+	// subscribers are never held up. Replayed streams cannot be fetched
+	// again so subscribers are waited on as for OverflowBlock when replaying.
+	OverflowResync
+)
+
+// ParseOverflowPolicy converts a config value ("block" or "resync") to an
+// OverflowPolicy, empty means OverflowBlock
+func ParseOverflowPolicy(s string) (OverflowPolicy, error) {
+	switch s {
+	case "", "block":
+		return OverflowBlock, nil
+	case "resync":
+		return OverflowResync, nil
+	default:
+		return OverflowBlock, fmt.Errorf("invalid subscriber overflow policy %q", s)
+	}
+}
+
+func (p OverflowPolicy) String() string {
+	if p == OverflowResync {
+		return "resync"
+	}
+	return "block"
+}
+
+// default number of batches queued per subscriber when Config.SubscriberBuffer
+// is unset
+const defaultSubscriberBuffer = 64
+
+// how often the queue length gauges are refreshed while idle
+const subscriberMetricsInterval = 5 * time.Second
+
+// Subscription is a subscriber's queue of batches, see SubscribeTopic
+type Subscription struct {
+	name   string
+	ch     chan *LogBatch
+	done   chan struct{}
+	resync atomic.Bool
+	// lock guards delivered, the ToBlock of the last batch queued, and the
+	// blocks dropped while resync is set that still need fetching again
+	lock        sync.Mutex
+	delivered   int64
+	missing     bool
+	missedFrom  int64
+	missedTo    int64
+	missedReset bool
+}
+
+// C returns the channel batches are delivered on, in block order
+func (s *Subscription) C() <-chan *LogBatch {
+	return s.ch
+}
+
+// ResyncRequired reports whether the subscriber fell too far behind under
+// OverflowResync and is being sent the batches it missed. It is cleared once
+// the subscriber has been sent everything that has been published.
+func (s *Subscription) ResyncRequired() bool {
+	return s.resync.Load()
+}
+
+// miss notes a batch dropped while the subscriber is being resynced. batches
+// are published in order and each replaces anything after its FromBlock, so
+// the range only ever ends at the latest batch.
+func (s *Subscription) miss(logs *LogBatch) {
+	if logs.Reset || !s.missing || logs.FromBlock < s.missedFrom {
+		s.missedFrom = logs.FromBlock
+	}
+	s.missedTo = logs.ToBlock
+	s.missedReset = logs.Reset || (s.missing && s.missedReset)
+	s.missing = true
+}
+
+// SubscribeTopic adds a subscriber for batches containing logs with the given
+// topics, name identifies the subscriber in logs and metrics. It must be
+// called before Start.
+func (rs *Watcher) SubscribeTopic(name string, eventTypes []common.Hash) *Subscription {
+	size := rs.config.SubscriberBuffer
+	if size < 1 {
+		size = defaultSubscriberBuffer
+	}
+	sub := &Subscription{
+		name:      name,
+		ch:        make(chan *LogBatch, size),
+		done:      make(chan struct{}),
+		delivered: -1,
+	}
+	rs.subscribersLock.Lock()
+	defer rs.subscribersLock.Unlock()
+	rs.subscribers = append(rs.subscribers, sub)
+	rs.topic0 = append(rs.topic0, eventTypes...)
+	return sub
+}
+
+// Unsubscribe stops publishing to the subscription. Its topics are still
+// fetched and its channel is not closed, so the subscriber should stop
+// reading from it rather than wait for it to close.
+func (rs *Watcher) Unsubscribe(sub *Subscription) {
+	rs.subscribersLock.Lock()
+	defer rs.subscribersLock.Unlock()
+	for i, s := range rs.subscribers {
+		if s == sub {
+			rs.subscribers = append(rs.subscribers[:i:i], rs.subscribers[i+1:]...)
+			close(sub.done)
+			subscriberQueueGauge.DeleteLabelValues(sub.name)
+			rs.log.Info().Str("subscriber", sub.name).Msg("unsubscribed")
+			return
+		}
+	}
+}
+
+func (rs *Watcher) currentSubscribers() []*Subscription {
+	rs.subscribersLock.Lock()
+	defer rs.subscribersLock.Unlock()
+	return rs.subscribers
+}
+
+func (rs *Watcher) publisher(ctx context.Context) {
+	ticker := time.NewTicker(subscriberMetricsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case logs := <-rs.sink:
+			rs.record(logs)
+			for _, sub := range rs.currentSubscribers() {
+				if !rs.publish(ctx, sub, logs) {
+					return
+				}
+			}
+			if logs.Reset || logs.Finalized > rs.finalized.Load() {
+				rs.finalized.Store(logs.Finalized)
+			}
+		case <-ticker.C:
+			for _, sub := range rs.currentSubscribers() {
+				subscriberQueueGauge.WithLabelValues(sub.name).Set(float64(len(sub.ch)))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// publish queues the batch for the subscriber, applying the overflow policy
+// if its queue is full. it returns false if ctx was cancelled.
+func (rs *Watcher) publish(ctx context.Context, sub *Subscription, logs *LogBatch) bool {
+	defer func() {
+		subscriberQueueGauge.WithLabelValues(sub.name).Set(float64(len(sub.ch)))
+	}()
+	if rs.offer(ctx, sub, logs) {
+		return true
+	}
+	rs.log.Warn().
+		Str("subscriber", sub.name).
+		Int("queued", len(sub.ch)).
+		Int64("from", logs.FromBlock).
+		Int64("to", logs.ToBlock).
+		Msg("subscriber-lagging")
+	start := time.Now()
+	select {
+	case sub.ch <- logs:
+		subscriberBlockedSeconds.WithLabelValues(sub.name).Add(time.Since(start).Seconds())
+		sub.lock.Lock()
+		sub.delivered = logs.ToBlock
+		sub.lock.Unlock()
+		return true
+	case <-sub.done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// offer queues the batch if the subscriber has room for it, or notes it as
+// missed if the subscriber is being resynced. If the queue is full under
+// OverflowResync it starts a resync, otherwise it returns false and the
+// caller must wait for room.
+func (rs *Watcher) offer(ctx context.Context, sub *Subscription, logs *LogBatch) bool {
+	sub.lock.Lock()
+	defer sub.lock.Unlock()
+	if sub.ResyncRequired() {
+		sub.miss(logs)
+		return true
+	}
+	select {
+	case sub.ch <- logs:
+		sub.delivered = logs.ToBlock
+		return true
+	default:
+	}
+	subscriberOverflowCounter.WithLabelValues(sub.name, rs.config.OverflowPolicy.String()).Inc()
+	if rs.config.OverflowPolicy != OverflowResync || rs.config.Replay != nil {
+		return false
+	}
+	rs.log.Error().
+		Str("subscriber", sub.name).
+		Int64("from", logs.FromBlock).
+		Int64("to", logs.ToBlock).
+		Msg("subscriber-resync-required")
+	sub.resync.Store(true)
+	sub.missing = false
+	sub.miss(logs)
+	go rs.redeliver(ctx, sub)
+	return true
+}
+
+// redeliver fetches the blocks the subscriber missed while resyncing and
+// queues them, waiting for it to make room rather than holding up the
+// publisher. It repeats until nothing more was missed while it was fetching,
+// then clears ResyncRequired so that publishing to the subscriber resumes.
+func (rs *Watcher) redeliver(ctx context.Context, sub *Subscription) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	query := ethereum.FilterQuery{Topics: [][]common.Hash{rs.topic0}}
+	for {
+		sub.lock.Lock()
+		if !sub.missing {
+			sub.resync.Store(false)
+			sub.lock.Unlock()
+			rs.log.Info().Str("subscriber", sub.name).Msg("subscriber-resynced")
+			return
+		}
+		from, to, reset := sub.missedFrom, sub.missedTo, sub.missedReset
+		reorg := !reset && from <= sub.delivered
+		sub.missing = false
+		sub.lock.Unlock()
+		rs.log.Warn().
+			Str("subscriber", sub.name).
+			Int64("from", from).
+			Int64("to", to).
+			Msg("subscriber-redeliver")
+		for logs := range rs.fetchRange(ctx, from, to, query) {
+			// the first batch replaces whatever the subscriber holds
+			// from its first block, as the dropped batches would have
+			logs.Reset, logs.Reorg = reset, reorg
+			reset, reorg = false, false
+			logs.Finalized = rs.Finalized()
+			select {
+			case sub.ch <- logs:
+			case <-sub.done:
+				return
+			case <-ctx.Done():
+				return
+			}
+			sub.lock.Lock()
+			sub.delivered = logs.ToBlock
+			sub.lock.Unlock()
+		}
+		if ctx.Err() != nil {
+			return // cancelled
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package eventwatcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/playmint/ds-node/pkg/client/alchemy"
+)
+
+var testTopic = common.HexToHash("0x01")
+
+// testChain serves eth_getLogs for a chain with one log in every block
+type testChain struct{}
+
+type testFilter struct {
+	FromBlock *hexutil.Big `json:"fromBlock"`
+	ToBlock   *hexutil.Big `json:"toBlock"`
+}
+
+func (testChain) GetLogs(ctx context.Context, filter testFilter) ([]types.Log, error) {
+	logs := []types.Log{}
+	for b := filter.FromBlock.ToInt().Uint64(); b <= filter.ToBlock.ToInt().Uint64(); b++ {
+		logs = append(logs, testLog(int64(b)))
+	}
+	return logs, nil
+}
+
+func testLog(block int64) types.Log {
+	return types.Log{
+		BlockNumber: uint64(block),
+		Topics:      []common.Hash{testTopic},
+		Data:        []byte{},
+	}
+}
+
+func testBatch(block int64) *LogBatch {
+	return &LogBatch{
+		EventBatch: EventBatch{FromBlock: block, ToBlock: block},
+		Logs:       []types.Log{testLog(block)},
+	}
+}
+
+// newTestWatcher returns a watcher whose http client is served by chain
+func newTestWatcher(t *testing.T, chain interface{}, cfg Config) *Watcher {
+	server := rpc.NewServer()
+	t.Cleanup(server.Stop)
+	if err := server.RegisterName("eth", chain); err != nil {
+		t.Fatal(err)
+	}
+	client, err := alchemy.NewClient(rpc.DialInProc(server), 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.HTTPClient = client
+	rs, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rs
+}
+
+func receive(t *testing.T, sub *Subscription) *LogBatch {
+	select {
+	case logs := <-sub.C():
+		return logs
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a batch")
+		return nil
+	}
+}
+
+func TestOverflowResyncRedelivers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rs := newTestWatcher(t, testChain{}, Config{
+		LogRange:         2,
+		SubscriberBuffer: 1,
+		OverflowPolicy:   OverflowResync,
+	})
+	sub := rs.SubscribeTopic("slow", []common.Hash{testTopic})
+	go rs.publisher(ctx)
+
+	// only the first batch fits in the queue, the rest are dropped
+	for block := int64(0); block <= 5; block++ {
+		rs.sink <- testBatch(block)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !sub.ResyncRequired() {
+		if time.Now().After(deadline) {
+			t.Fatal("subscriber was never marked as needing a resync")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// every block arrives exactly once and in order
+	next := int64(0)
+	for next <= 5 {
+		logs := receive(t, sub)
+		if logs.FromBlock != next || logs.Reorg || logs.Reset {
+			t.Fatalf("got batch %d-%d reorg=%v reset=%v, want one starting at %d", logs.FromBlock, logs.ToBlock, logs.Reorg, logs.Reset, next)
+		}
+		for i, log := range logs.Logs {
+			if int64(log.BlockNumber) != next+int64(i) {
+				t.Fatalf("batch %d-%d has log for block %d", logs.FromBlock, logs.ToBlock, log.BlockNumber)
+			}
+		}
+		next = logs.ToBlock + 1
+	}
+
+	// once caught up, publishing resumes
+	deadline = time.Now().Add(5 * time.Second)
+	for sub.ResyncRequired() {
+		if time.Now().After(deadline) {
+			t.Fatal("subscriber never recovered")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	rs.sink <- testBatch(6)
+	if logs := receive(t, sub); logs.FromBlock != 6 || logs.ToBlock != 6 {
+		t.Fatalf("got batch %d-%d after recovering, want 6-6", logs.FromBlock, logs.ToBlock)
+	}
+}
+
+func TestSubscriptionMiss(t *testing.T) {
+	tests := []struct {
+		name      string
+		dropped   []*LogBatch
+		wantFrom  int64
+		wantTo    int64
+		wantReset bool
+	}{
+		{
+			name:     "extending batches",
+			dropped:  []*LogBatch{testBatch(5), testBatch(6), testBatch(7)},
+			wantFrom: 5,
+			wantTo:   7,
+		},
+		{
+			name: "reorg behind the first dropped batch",
+			dropped: []*LogBatch{
+				testBatch(5),
+				{EventBatch: EventBatch{FromBlock: 3, ToBlock: 6}, Reorg: true},
+			},
+			wantFrom: 3,
+			wantTo:   6,
+		},
+		{
+			name: "reorg to a shorter chain",
+			dropped: []*LogBatch{
+				{EventBatch: EventBatch{FromBlock: 5, ToBlock: 9}},
+				{EventBatch: EventBatch{FromBlock: 7, ToBlock: 8}, Reorg: true},
+			},
+			wantFrom: 5,
+			wantTo:   8,
+		},
+		{
+			name: "reset starts again",
+			dropped: []*LogBatch{
+				testBatch(5),
+				{EventBatch: EventBatch{FromBlock: 0, ToBlock: 2}, Reset: true},
+				testBatch(3),
+			},
+			wantFrom:  0,
+			wantTo:    3,
+			wantReset: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &Subscription{}
+			for _, logs := range tt.dropped {
+				sub.miss(logs)
+			}
+			if sub.missedFrom != tt.wantFrom || sub.missedTo != tt.wantTo || sub.missedReset != tt.wantReset {
+				t.Fatalf("missed %d-%d reset=%v, want %d-%d reset=%v", sub.missedFrom, sub.missedTo, sub.missedReset, tt.wantFrom, tt.wantTo, tt.wantReset)
+			}
+		})
+	}
+}
@@ -3,13 +3,18 @@ package eventwatcher
 import (
 	"context"
 	"fmt"
+	"io"
 	"math/big"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/playmint/ds-node/pkg/client"
 	"github.com/playmint/ds-node/pkg/client/alchemy"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -23,6 +28,26 @@ type EventBatch struct {
 type LogBatch struct {
 	EventBatch
 	Logs []types.Log
+	// Reorg is set when the chain has reorganised and this batch holds the
+	// canonical logs for every block from FromBlock onwards, replacing any logs
+	// previously published for those blocks
+	Reorg bool
+	// Reset is set when the chain has been replaced entirely, such as a dev
+	// chain being reset, and this batch starts again from the first block.
+	// Subscribers must discard everything they hold before applying it.
+	Reset bool
+	// Finalized is the most recent block known to be final when the
+	// batch was fetched, nothing at or before it will be reorged
+	Finalized int64
+}
+
+// Rewind returns the block that subscribers should roll their state back to
+// before applying the batch, ok is false for batches that only extend the chain
+func (b *LogBatch) Rewind() (block int64, ok bool) {
+	if !b.Reorg {
+		return 0, false
+	}
+	return b.FromBlock - 1, true
 }
 
 type Config struct {
@@ -32,29 +57,107 @@ type Config struct {
 	LogRange   int
 	Simulated  bool
 	Addresses  []common.Address
+	ReorgDepth int
+	// Concurrency is the maximum number of batches fetched at
+	// the same time while catching up with the chain
+	Concurrency int
+	// PollInterval is how often to poll for new blocks over http when
+	// there is no Websocket client or it has failed too many times
+	PollInterval         time.Duration
+	MaxWebsocketFailures int
+	// Confirmations is how deep a block must be before it is considered
+	// final, used when FinalizedTag is unset or the chain does not support
+	// the "finalized" block tag
+	Confirmations int
+	FinalizedTag  bool
+	// SubscriberBuffer is the number of batches queued for each subscriber
+	// before OverflowPolicy applies
+	SubscriberBuffer int
+	OverflowPolicy   OverflowPolicy
+	// Replay, if set, is a recorded stream of logs to publish instead of
+	// fetching them from the chain, see replay
+	Replay io.Reader
+	// Recorder, if set, is sent every published batch in the format read
+	// by Replay
+	Recorder io.Writer
 }
 
 type Watcher struct {
 	sink        chan *LogBatch
 	ready       chan struct{}
-	subscribers []chan *LogBatch
+	failed      chan error
+	subscribers []*Subscription
 	topic0      []common.Hash
 	config      Config
 	log         zerolog.Logger
 	stop        func()
+	// resync requests republishing everything, see Resync
+	resync    chan struct{}
+	resetting bool
+	// hashes of recently seen heads, used for detecting reorgs
+	hashes    map[int64]common.Hash
+	lastBlock int64
+	// readyBlock is the ToBlock of the last batch published before ready
+	// was closed, -1 if there were none
+	readyBlock int64
+	// logRange is the current number of blocks per batch, it shrinks when
+	// the provider rejects a range and grows back after successful batches
+	logRange          int64
+	logRangeSuccesses int
+	logRangeLock      sync.Mutex
+	// set once we discover the provider does not support the finalized tag
+	finalizedTagUnsupported bool
+	// addresses is the set of contracts being watched, nil means every
+	// contract. It can grow at runtime, see WatchAddresses.
+	addresses       map[common.Address]bool
+	discoverers     []Discoverer
+	addressesLock   sync.RWMutex
+	subscribersLock sync.Mutex
+	// finalized is the Finalized block of the most recently published
+	// batch, -1 before the first
+	finalized atomic.Int64
 }
 
+// longest wait between attempts to fetch the head before the initial catch up
+const maxHeadBackoff = 30 * time.Second
+
+// number of full size batches that need to succeed before the log range is
+// allowed to grow again after it has been shrunk
+const logRangeGrowAfter = 10
+
 func New(cfg Config) (*Watcher, error) {
 	if cfg.LogRange < 1 {
 		return nil, fmt.Errorf("invalid log range config")
 	}
-	return &Watcher{
+	logRangeGauge.Set(float64(cfg.LogRange))
+	var addresses map[common.Address]bool
+	if len(cfg.Addresses) > 0 {
+		addresses = map[common.Address]bool{}
+		for _, addr := range cfg.Addresses {
+			addresses[addr] = true
+		}
+	}
+	rs := &Watcher{
 		sink:        make(chan *LogBatch, 1024),
-		subscribers: []chan *LogBatch{},
+		subscribers: []*Subscription{},
 		ready:       make(chan struct{}),
+		failed:      make(chan error, 1),
+		resync:      make(chan struct{}, 1),
 		config:      cfg,
+		hashes:      map[int64]common.Hash{},
+		logRange:    int64(cfg.LogRange),
+		addresses:   addresses,
 		log:         log.With().Str("service", "indexer").Str("component", "eventwatcher").Bool("simulated", cfg.Simulated).Int64("epoch", cfg.EpochBlock).Logger(),
-	}, nil
+	}
+	rs.finalized.Store(-1)
+	rs.readyBlock = -1
+	return rs, nil
+}
+
+// Finalized returns the most recent block known to be final as of the last
+// published batch, or -1 if nothing has been published yet
+func (rs *Watcher) Finalized() int64 {
+	return rs.finalized.Load()
 }
 
 func (rs *Watcher) Stop() {
@@ -65,48 +168,149 @@ func (rs *Watcher) Stop() {
 
 func (rs *Watcher) Start(ctx context.Context) {
 	rs.log.Info().Msg("watcher-start")
-	topicQuery := ethereum.FilterQuery{Topics: [][]common.Hash{rs.topic0}, Addresses: rs.config.Addresses}
+	// addresses are filled in for each batch as the set can change
+	topicQuery := ethereum.FilterQuery{Topics: [][]common.Hash{rs.topic0}}
 	ctx, rs.stop = context.WithCancel(ctx)
-	go rs.watch(ctx, topicQuery)
+	if rs.config.Replay != nil {
+		go func() {
+			if err := rs.replay(ctx); err != nil {
+				rs.log.Error().Err(err).Msg("replay-fail")
+				rs.failed <- err
+			}
+		}()
+	} else {
+		go rs.watch(ctx, topicQuery)
+	}
 	go rs.publisher(ctx)
 }
 
 func (rs *Watcher) fetchEvents(ctx context.Context, query ethereum.FilterQuery) {
-	nowBlockRes, err := rs.config.HTTPClient.BlockNumber(ctx)
-	if err != nil {
-		rs.log.Error().Err(err).Msg("fetch-events-get-block")
-		return
+	head := rs.headWithRetry(ctx)
+	if head == nil {
+		return // cancelled
 	}
-	nowBlock := int64(nowBlockRes)
-	fromBlock := rs.config.EpochBlock
-	batchSize := int64(rs.config.LogRange)
+	nowBlock := head.Number.Int64()
+	finalized := rs.finalizedBlock(ctx, nowBlock)
+	rs.discoverAll(ctx, nowBlock)
 
+	for logs := range rs.fetchRange(ctx, rs.config.EpochBlock, nowBlock, query) {
+		logs.Finalized = finalized
+		select {
+		case rs.sink <- logs:
+		case <-ctx.Done():
+			return
+		}
+		rs.readyBlock = logs.ToBlock
+	}
+	if ctx.Err() != nil {
+		return // cancelled
+	}
+
+	// remember where we got to so we can spot if the chain
+	// reorgs underneath us once we start following the head
+	rs.hashes[nowBlock] = head.Hash()
+	rs.lastBlock = nowBlock
+
+	close(rs.ready)
+}
+
+// headWithRetry fetches the current head, retrying with a growing delay
+// until it succeeds. It returns nil if ctx is cancelled first.
+func (rs *Watcher) headWithRetry(ctx context.Context) *types.Header {
+	backoff := time.Second
 	for {
-		if fromBlock > nowBlock {
-			break // done
+		head, err := rs.config.HTTPClient.HeaderByNumber(ctx, nil)
+		if err == nil {
+			return head
 		}
-		toBlock := min(fromBlock+batchSize-1, nowBlock)
-		if fromBlock >= toBlock {
-			break
+		rs.log.Error().
+			Err(err).
+			Dur("retry", backoff).
+			Msg("fetch-events-get-block")
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil
 		}
-		batch := EventBatch{
-			FromBlock: fromBlock,
-			ToBlock:   toBlock,
+		if backoff *= 2; backoff > maxHeadBackoff {
+			backoff = maxHeadBackoff
 		}
-		rs.getBatchWithRetry(ctx, batch, query)
-		fromBlock = fromBlock + batchSize
 	}
+}
 
-	close(rs.ready)
+// fetchRange fetches the logs for every block from fromBlock to toBlock in
+// batches, up to Concurrency of them at the same time. Each batch gets a slot
+// in the results queue in block order so that they are returned in the same
+// order they would have been if fetched one after another, and holds a slot
+// in sem while in flight so that no more than Concurrency requests are ever
+// made at once. The channel is closed once every batch has been returned or
+// ctx is cancelled.
+func (rs *Watcher) fetchRange(ctx context.Context, fromBlock int64, toBlock int64, query ethereum.FilterQuery) <-chan *LogBatch {
+	out := make(chan *LogBatch)
+	results := make(chan chan *LogBatch, rs.concurrency())
+	sem := make(chan struct{}, rs.concurrency())
+	go func() {
+		defer close(results)
+		for fromBlock <= toBlock {
+			batchSize := rs.currentLogRange()
+			batch := EventBatch{
+				FromBlock: fromBlock,
+				ToBlock:   min(fromBlock+batchSize-1, toBlock),
+			}
+			result := make(chan *LogBatch, 1)
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case results <- result:
+			case <-ctx.Done():
+				return
+			}
+			go func() {
+				defer func() { <-sem }()
+				result <- rs.getBatchWithRetry(ctx, batch, query, false)
+			}()
+			fromBlock = batch.ToBlock + 1
+		}
+	}()
+	go func() {
+		defer close(out)
+		for result := range results {
+			logs := <-result
+			if logs == nil {
+				return // cancelled
+			}
+			select {
+			case out <- logs:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func (rs *Watcher) concurrency() int {
+	if rs.config.Concurrency < 1 {
+		return 1
+	}
+	return rs.config.Concurrency
 }
 
-func (rs *Watcher) getBatchWithRetry(ctx context.Context, batch EventBatch, query ethereum.FilterQuery) {
+// getBatchWithRetry fetches the batch retrying until it succeeds, it only
+// returns nil if the context is cancelled
+func (rs *Watcher) getBatchWithRetry(ctx context.Context, batch EventBatch, query ethereum.FilterQuery, reorg bool) *LogBatch {
 	for {
 		select {
 		case <-ctx.Done():
-			return
+			return nil
 		default:
-			if err := rs.getBatch(ctx, batch, query); err != nil {
+			logs, err := rs.getBatch(ctx, batch, query, reorg)
+			if err != nil && client.IsRangeTooLarge(err) && batch.ToBlock > batch.FromBlock {
+				return rs.getSplitBatch(ctx, batch, query, reorg)
+			} else if err != nil {
 				rs.log.Error().
 					Err(err).
 					Int64("from", batch.FromBlock).
@@ -115,40 +319,129 @@ func (rs *Watcher) getBatchWithRetry(ctx context.Context, batch EventBatch, quer
 				time.Sleep(time.Second)
 				continue
 			}
-			return
+			rs.growLogRange(batch)
+			return logs
 		}
 	}
 }
 
-func (rs *Watcher) getBatch(ctx context.Context, batch EventBatch, query ethereum.FilterQuery) error {
+// getSplitBatch bisects a batch that the provider rejected as too large and
+// fetches each half (splitting further if needed) before joining them back up
+func (rs *Watcher) getSplitBatch(ctx context.Context, batch EventBatch, query ethereum.FilterQuery, reorg bool) *LogBatch {
+	mid := batch.FromBlock + (batch.ToBlock-batch.FromBlock)/2
+	rs.shrinkLogRange(mid - batch.FromBlock + 1)
+	rs.log.Warn().
+		Int64("from", batch.FromBlock).
+		Int64("to", batch.ToBlock).
+		Msg("split-batch")
+	first := rs.getBatchWithRetry(ctx, EventBatch{FromBlock: batch.FromBlock, ToBlock: mid}, query, reorg)
+	if first == nil {
+		return nil
+	}
+	second := rs.getBatchWithRetry(ctx, EventBatch{FromBlock: mid + 1, ToBlock: batch.ToBlock}, query, reorg)
+	if second == nil {
+		return nil
+	}
+	return &LogBatch{
+		EventBatch: batch,
+		Logs:       append(first.Logs, second.Logs...),
+		Reorg:      reorg,
+	}
+}
+
+func (rs *Watcher) currentLogRange() int64 {
+	rs.logRangeLock.Lock()
+	defer rs.logRangeLock.Unlock()
+	return rs.logRange
+}
+
+func (rs *Watcher) shrinkLogRange(size int64) {
+	rs.logRangeLock.Lock()
+	defer rs.logRangeLock.Unlock()
+	rs.logRangeSuccesses = 0
+	if size < 1 {
+		size = 1
+	}
+	if size >= rs.logRange {
+		return
+	}
+	rs.logRange = size
+	logRangeGauge.Set(float64(size))
+	rs.log.Warn().Int64("range", size).Msg("log-range-shrink")
+}
+
+func (rs *Watcher) growLogRange(batch EventBatch) {
+	rs.logRangeLock.Lock()
+	defer rs.logRangeLock.Unlock()
+	maxRange := int64(rs.config.LogRange)
+	if rs.logRange >= maxRange || batch.ToBlock-batch.FromBlock+1 < rs.logRange {
+		return
+	}
+	rs.logRangeSuccesses++
+	if rs.logRangeSuccesses < logRangeGrowAfter {
+		return
+	}
+	rs.logRangeSuccesses = 0
+	rs.logRange = min(rs.logRange*2, maxRange)
+	logRangeGauge.Set(float64(rs.logRange))
+	rs.log.Info().Int64("range", rs.logRange).Msg("log-range-grow")
+}
+
+func (rs *Watcher) getBatch(ctx context.Context, batch EventBatch, query ethereum.FilterQuery, reorg bool) (*LogBatch, error) {
 	query.FromBlock = big.NewInt(batch.FromBlock)
 	query.ToBlock = big.NewInt(batch.ToBlock)
+	if query.Addresses == nil {
+		query.Addresses = rs.watchedAddresses()
+	}
 	logs, err := rs.config.HTTPClient.FilterLogs(ctx, query)
 	if err != nil {
-		return err
-	}
-	rs.sink <- &LogBatch{
-		EventBatch: batch,
-		Logs:       logs,
+		return nil, err
 	}
 	rs.log.Info().
 		Int64("from", batch.FromBlock).
 		Int64("to", batch.ToBlock).
 		Int("logs", len(logs)).
 		Msg("searching")
-	return nil
+	return &LogBatch{
+		EventBatch: batch,
+		Logs:       logs,
+		Reorg:      reorg,
+	}, nil
 }
 
 func (rs *Watcher) watch(ctx context.Context, query ethereum.FilterQuery) {
 	rs.fetchEvents(ctx, query)
-	<-rs.ready
+	select {
+	case <-rs.ready:
+	case <-ctx.Done():
+		return
+	}
+	wsFailures := 0
 	for {
 		select {
 		case <-ctx.Done():
 			rs.log.Info().Msg("done")
 			return
 		default:
-			if err := rs.subscribeHead(ctx, query); err != nil {
+			var err error
+			if rs.config.Websocket == nil || (rs.config.MaxWebsocketFailures > 0 && wsFailures >= rs.config.MaxWebsocketFailures) {
+				err = rs.pollHead(ctx, query)
+			} else {
+				lastBlock := rs.lastBlock
+				err = rs.subscribeHead(ctx, query)
+				if rs.lastBlock > lastBlock {
+					wsFailures = 0
+				}
+				if err != nil {
+					wsFailures++
+					if wsFailures == rs.config.MaxWebsocketFailures {
+						rs.log.Warn().
+							Int("failures", wsFailures).
+							Msg("websocket-fallback-to-polling")
+					}
+				}
+			}
+			if err != nil {
 				rs.log.Error().
 					Err(err).
 					Msg("subscribe-fail")
@@ -158,6 +451,48 @@ func (rs *Watcher) watch(ctx context.Context, query ethereum.FilterQuery) {
 	}
 }
 
+// pollHead follows the chain head by polling eth_blockNumber over http, for
+// when the provider does not support websocket subscriptions
+func (rs *Watcher) pollHead(ctx context.Context, query ethereum.FilterQuery) error {
+	rs.log.Info().Dur("interval", rs.pollInterval()).Msg("polling")
+	defer rs.log.Info().Msg("stopped-polling")
+	ticker := time.NewTicker(rs.pollInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-rs.resync:
+			if err := rs.resyncHead(ctx, query); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			number, err := rs.config.HTTPClient.BlockNumber(ctx)
+			if err != nil {
+				return fmt.Errorf("poll: %v", err)
+			}
+			if int64(number) <= rs.lastBlock {
+				continue
+			}
+			// processHead fills in any blocks between this and the last
+			head, err := rs.config.HTTPClient.HeaderByNumber(ctx, big.NewInt(int64(number)))
+			if err != nil {
+				return fmt.Errorf("poll: failed to fetch header %d: %v", number, err)
+			}
+			if err := rs.processHead(ctx, head, query); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (rs *Watcher) pollInterval() time.Duration {
+	if rs.config.PollInterval <= 0 {
+		return time.Second
+	}
+	return rs.config.PollInterval
+}
+
 func (rs *Watcher) subscribeHead(ctx context.Context, query ethereum.FilterQuery) error {
 	rs.log.Info().Msg("subscribed")
 	defer rs.log.Info().Msg("unsubscribed")
@@ -167,6 +502,14 @@ func (rs *Watcher) subscribeHead(ctx context.Context, query ethereum.FilterQuery
 		return err
 	}
 	defer sub.Unsubscribe()
+	// catch up on anything mined while we were not subscribed
+	head, err := rs.config.HTTPClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := rs.processHead(ctx, head, query); err != nil {
+		return err
+	}
 	return rs.watcher(ctx, sub, blocks, query)
 }
 
@@ -176,41 +519,202 @@ func (rs *Watcher) watcher(ctx context.Context, sub event.Subscription, blocks c
 		case err := <-sub.Err():
 			return fmt.Errorf("suberr: %v", err)
 		case block := <-blocks:
-			rs.getBatchWithRetry(ctx, EventBatch{
-				FromBlock: block.Number.Int64(),
-				ToBlock:   block.Number.Int64(),
-			}, query)
+			if err := rs.processHead(ctx, block, query); err != nil {
+				return err
+			}
+		case <-rs.resync:
+			if err := rs.resyncHead(ctx, query); err != nil {
+				return err
+			}
 		case <-ctx.Done():
 			return nil
 		}
 	}
 }
 
-func (rs *Watcher) publisher(ctx context.Context) {
+// processHead fetches the logs for a new head along with any blocks between
+// the last published block and the head that we missed. If the head does not
+// build on the blocks we have already published, it walks back to the common
+// ancestor and publishes a reorg batch with the canonical logs from that point.
+func (rs *Watcher) processHead(ctx context.Context, head *types.Header, query ethereum.FilterQuery) error {
+	number := head.Number.Int64()
+	if seen, ok := rs.hashes[number]; ok && seen == head.Hash() {
+		return nil // already published
+	}
+	ancestor := number - 1
+	canonical := head.ParentHash
+	if ancestor > rs.lastBlock {
+		// there is a gap between the head and the last block we published,
+		// check that the last block is still canonical before filling it
+		ancestor = rs.lastBlock
+		header, err := rs.config.HTTPClient.HeaderByNumber(ctx, big.NewInt(ancestor))
+		if err != nil {
+			return fmt.Errorf("gap: failed to fetch header %d: %v", ancestor, err)
+		}
+		canonical = header.Hash()
+	}
+	replaced := map[int64]common.Hash{}
 	for {
-		select {
-		case logs := <-rs.sink:
-			for _, sub := range rs.subscribers {
-				sub <- logs
-			}
-		case <-ctx.Done():
-			return
+		seen, ok := rs.hashes[ancestor]
+		if !ok || seen == canonical {
+			break
 		}
+		replaced[ancestor] = canonical
+		ancestor--
+		if _, ok := rs.hashes[ancestor]; !ok {
+			rs.log.Error().
+				Int64("block", number).
+				Int64("ancestor", ancestor).
+				Msg("reorg-deeper-than-history")
+			break
+		}
+		header, err := rs.config.HTTPClient.HeaderByNumber(ctx, big.NewInt(ancestor))
+		if err != nil {
+			return fmt.Errorf("reorg: failed to fetch header %d: %v", ancestor, err)
+		}
+		canonical = header.Hash()
+	}
+
+	reorg := ancestor < rs.lastBlock
+	if reorg {
+		rs.log.Warn().
+			Int64("block", number).
+			Int64("ancestor", ancestor).
+			Int64("depth", rs.lastBlock-ancestor).
+			Msg("reorg")
+	} else if ancestor < number-1 {
+		rs.log.Warn().
+			Int64("from", ancestor+1).
+			Int64("to", number-1).
+			Msg("backfill-gap")
+	}
+	finalized := rs.finalizedBlock(ctx, number)
+	if !rs.publishRange(ctx, ancestor+1, number, finalized, query, reorg) {
+		return nil // cancelled
+	}
+
+	// update the known hashes, forgetting anything that
+	// was replaced or is now too old to care about
+	for n := range rs.hashes {
+		if n > ancestor || n <= number-int64(rs.reorgDepth()) {
+			delete(rs.hashes, n)
+		}
+	}
+	for n, hash := range replaced {
+		rs.hashes[n] = hash
+	}
+	rs.hashes[number] = head.Hash()
+	rs.lastBlock = number
+	return nil
+}
+
+// Resync discards everything published so far and publishes every log again
+// from the first block, the first batch is marked as Reset. It is for when
+// the chain has been replaced underneath the watcher, such as a dev chain
+// being reset, and is ignored when replaying.
+func (rs *Watcher) Resync() {
+	select {
+	case rs.resync <- struct{}{}:
+	default: // already requested
+	}
+}
+
+func (rs *Watcher) resyncHead(ctx context.Context, query ethereum.FilterQuery) error {
+	head, err := rs.config.HTTPClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		rs.Resync() // try again once reconnected
+		return fmt.Errorf("resync: %v", err)
+	}
+	number := head.Number.Int64()
+	rs.log.Warn().Int64("to", number).Msg("resync")
+	// anything from a checkpoint or the old chain no longer applies
+	rs.config.EpochBlock = 0
+	rs.hashes = map[int64]common.Hash{}
+	rs.lastBlock = -1
+	rs.resetting = true
+	rs.resetAddresses()
+	finalized := rs.finalizedBlock(ctx, number)
+	if !rs.publishRange(ctx, 0, number, finalized, query, true) {
+		return nil // cancelled
+	}
+	rs.hashes[number] = head.Hash()
+	rs.lastBlock = number
+	return nil
+}
+
+// publishRange fetches and publishes the logs between from and to in order,
+// in batches no larger than the current log range. If reorg is set then the
+// first batch is marked as replacing everything from that block onwards. It
+// only returns false if the context is cancelled.
+func (rs *Watcher) publishRange(ctx context.Context, from int64, to int64, finalized int64, query ethereum.FilterQuery, reorg bool) bool {
+	for from <= to {
+		batch := EventBatch{
+			FromBlock: from,
+			ToBlock:   min(from+rs.currentLogRange()-1, to),
+		}
+		logs := rs.getBatchWithRetry(ctx, batch, query, reorg)
+		if logs == nil {
+			return false
+		}
+		// the batch was fetched without any contracts it announces, fetch
+		// again including them from the block they were deployed in
+		if deployed, ok := rs.discover(ctx, logs); ok && deployed <= batch.ToBlock {
+			rs.log.Warn().
+				Int64("from", deployed).
+				Int64("to", to).
+				Msg("backfill-discovered")
+			reorg = reorg || deployed < from
+			from = min(from, deployed)
+			continue
+		}
+		logs.Finalized = finalized
+		logs.Reset = rs.resetting
+		rs.sink <- logs
+		rs.resetting = false
+		reorg = false
+		from = batch.ToBlock + 1
+	}
+	return true
+}
 
+// finalizedBlock returns the most recent block that can no longer be reorged,
+// using the chain's finalized tag if enabled and supported, otherwise by
+// counting Confirmations back from head
+func (rs *Watcher) finalizedBlock(ctx context.Context, head int64) int64 {
+	if rs.config.FinalizedTag && !rs.finalizedTagUnsupported {
+		header, err := rs.config.HTTPClient.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+		if err == nil {
+			return header.Number.Int64()
+		}
+		rs.log.Warn().Err(err).Msg("finalized-tag-unsupported")
+		rs.finalizedTagUnsupported = true
 	}
+	return head - int64(rs.config.Confirmations)
+}
+
+func (rs *Watcher) reorgDepth() int {
+	if rs.config.ReorgDepth < 1 {
+		return 1
+	}
+	return rs.config.ReorgDepth
 }
 
 func (rs *Watcher) Ready() chan struct{} {
 	return rs.ready
 }
 
-func (rs *Watcher) SubscribeTopic(eventTypes []common.Hash) chan *LogBatch {
-	ch := make(chan *LogBatch, 0)
-	rs.subscribers = append(rs.subscribers, ch)
-	for _, topic := range eventTypes {
-		rs.topic0 = append(rs.topic0, topic)
-	}
-	return ch
+// Failed is sent the error that stopped a replay before the end of the
+// stream, Ready is never closed if it is. Watching the chain retries forever
+// and never fails.
+func (rs *Watcher) Failed() <-chan error {
+	return rs.failed
+}
+
+// ReadyBlock returns the last block published by the initial catch up, a
+// subscriber has caught up once it has processed a batch ending there. It is
+// -1 if nothing was published and must only be called once Ready is closed.
+func (rs *Watcher) ReadyBlock() int64 {
+	return rs.readyBlock
 }
 
 func min(a, b int64) int64 {
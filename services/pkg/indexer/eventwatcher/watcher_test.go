@@ -0,0 +1,78 @@
+package eventwatcher
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// headChain is a testChain at block head that fails the first failures
+// requests for a block header
+type headChain struct {
+	testChain
+	head     int64
+	failures *atomic.Int32
+}
+
+func (c headChain) GetBlockByNumber(ctx context.Context, number string, full bool) (*types.Header, error) {
+	if c.failures.Add(-1) >= 0 {
+		return nil, fmt.Errorf("unavailable")
+	}
+	return &types.Header{Number: big.NewInt(c.head), Difficulty: big.NewInt(0)}, nil
+}
+
+func TestFetchEventsRetriesHead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	failures := &atomic.Int32{}
+	failures.Store(1)
+	rs := newTestWatcher(t, headChain{head: 3, failures: failures}, Config{LogRange: 2})
+	sub := rs.SubscribeTopic("test", []common.Hash{testTopic})
+	rs.Start(ctx)
+	select {
+	case <-rs.Ready():
+	case <-time.After(5 * time.Second):
+		t.Fatal("never became ready after the head was fetched")
+	}
+	next := int64(0)
+	for next <= 3 {
+		logs := receive(t, sub)
+		if logs.FromBlock != next {
+			t.Fatalf("got batch %d-%d, want one starting at %d", logs.FromBlock, logs.ToBlock, next)
+		}
+		next = logs.ToBlock + 1
+	}
+	if rs.ReadyBlock() != 3 {
+		t.Fatalf("got ready block %d, want 3", rs.ReadyBlock())
+	}
+}
+
+func TestWatchCancelledBeforeHead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	failures := &atomic.Int32{}
+	failures.Store(1 << 30)
+	rs := newTestWatcher(t, headChain{head: 3, failures: failures}, Config{LogRange: 2})
+	done := make(chan struct{})
+	go func() {
+		rs.watch(ctx, ethereum.FilterQuery{})
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch did not return once cancelled")
+	}
+	select {
+	case <-rs.Ready():
+		t.Fatal("ready without having fetched anything")
+	default:
+	}
+}
@@ -2,14 +2,24 @@ package indexer
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/playmint/ds-node/pkg/api/model"
 	"github.com/playmint/ds-node/pkg/client/alchemy"
 	"github.com/playmint/ds-node/pkg/config"
+	"github.com/playmint/ds-node/pkg/indexer/checkpoint"
 	"github.com/playmint/ds-node/pkg/indexer/eventwatcher"
+	"github.com/playmint/ds-node/pkg/indexer/stores"
 	"github.com/playmint/ds-node/pkg/indexer/stores/cog"
 	"github.com/playmint/ds-node/pkg/indexer/stores/configstore"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 )
 
 type Indexer interface {
@@ -18,11 +28,12 @@ type Indexer interface {
 	// query funcs
 	GetGame(id string) *model.Game
 	GetGames() []*model.Game
-	GetGraph(stateContractAddr common.Address, block int, simulated bool) *model.Graph
+	GetGraph(stateContractAddr common.Address, block int, simulated bool, finalized bool) (*model.Graph, error)
 	GetSession(routerAddr common.Address, sessionID string) *model.Session
 	GetSessions(routerAddr common.Address, owner *string) []*model.Session
-	AddPendingOpSet(estimatedBlockNumber int, opset cog.OpSet)
-	RemovePendingOpSets(opset map[string]bool)
+	AddPendingOpSet(stateContractAddr common.Address, estimatedBlockNumber int, opset cog.OpSet)
+	RemovePendingOpSets(stateContractAddr common.Address, opset map[string]bool)
+	QueryStore(ctx context.Context, name string, key *string) (interface{}, error)
 }
 
 var _ Indexer = &MemoryIndexer{}
@@ -32,35 +43,55 @@ type MemoryIndexer struct {
 	gameStore     *cog.GameStore
 	stateStore    *cog.StateStore
 	sessionStore  *cog.SessionStore
+	customStores  map[string]stores.Store
+	storesReady   []<-chan struct{}
+	ready         chan struct{}
 	notifications chan interface{}
 	events        *eventwatcher.Watcher
 	httpClient    *alchemy.Client
 	wsClient      *alchemy.Client
+	checkpoints   checkpoint.Backend
+	resynced      bool
+	resyncLock    sync.Mutex
+	chainID       uint64
+	log           zerolog.Logger
 }
 
 func NewMemoryIndexer(ctx context.Context, notifications chan interface{}, httpProviderURL string, wsProviderURL string) (*MemoryIndexer, error) {
 	var err error
 
-	idxr := &MemoryIndexer{}
+	idxr := &MemoryIndexer{
+		ready: make(chan struct{}),
+		log:   log.With().Str("service", "indexer").Str("component", "checkpoint").Logger(),
+	}
 
 	idxr.notifications = notifications
 
-	idxr.httpClient, err = alchemy.Dial(
-		httpProviderURL,
-		config.IndexerMaxConcurrency,
-		nil,
-	)
-	if err != nil {
-		return nil, err
+	// the provider is only optional when replaying, without one nothing is
+	// looked up on chain, such as game metadata
+	if httpProviderURL != "" {
+		idxr.httpClient, err = alchemy.Dial(
+			httpProviderURL,
+			config.IndexerMaxConcurrency,
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+	} else if config.IndexerReplayPath == "" {
+		return nil, fmt.Errorf("INDEXER_PROVIDER_URL_HTTP is required unless replaying")
 	}
 
-	idxr.wsClient, err = alchemy.Dial(
-		wsProviderURL,
-		config.IndexerMaxConcurrency,
-		nil,
-	)
-	if err != nil {
-		return nil, err
+	// websockets are optional, without them we fallback to polling
+	if wsProviderURL != "" {
+		idxr.wsClient, err = alchemy.Dial(
+			wsProviderURL,
+			config.IndexerMaxConcurrency,
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var contractAddrs []common.Address
@@ -75,21 +106,106 @@ func NewMemoryIndexer(ctx context.Context, notifications chan interface{}, httpP
 		contractAddrs = append(contractAddrs, config.IndexerRouterAddress)
 	}
 
+	// find where to resume indexing from
+	if config.IndexerCheckpointPath != "" || config.IndexerSnapshotPath != "" {
+		if idxr.httpClient == nil {
+			return nil, fmt.Errorf("checkpoints and snapshots require INDEXER_PROVIDER_URL_HTTP")
+		}
+		chainID, err := idxr.httpClient.ChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		idxr.chainID = chainID.Uint64()
+	}
+	var cp *checkpoint.Checkpoint
+	if config.IndexerCheckpointPath != "" {
+		idxr.checkpoints = checkpoint.NewFileBackend(config.IndexerCheckpointPath)
+		cp, err = idxr.checkpoints.Load()
+		if err != nil {
+			return nil, err
+		}
+		cp = idxr.usable(cp, "checkpoint")
+	}
+	// seed from a snapshot exported by another node, unless we have
+	// already indexed past it
+	if config.IndexerSnapshotPath != "" {
+		snapshot, err := checkpoint.LoadSnapshot(config.IndexerSnapshotPath)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot = idxr.usable(snapshot, "snapshot"); snapshot != nil && (cp == nil || cp.Block < snapshot.Block) {
+			idxr.log.Info().
+				Str("path", config.IndexerSnapshotPath).
+				Int64("block", snapshot.Block).
+				Msg("loading-snapshot")
+			cp = snapshot
+		}
+	}
+	var epochBlock int64
+	if cp != nil {
+		epochBlock = cp.Block + 1
+	}
+
+	// replay recorded logs instead of fetching them, and/or record
+	// everything that is published for replaying later
+	var replay io.Reader
+	if config.IndexerReplayPath != "" {
+		f, err := os.Open(config.IndexerReplayPath)
+		if err != nil {
+			return nil, err
+		}
+		replay = f
+	}
+	var recorder io.Writer
+	if config.IndexerRecordPath != "" {
+		f, err := os.OpenFile(config.IndexerRecordPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+		go func() {
+			<-ctx.Done()
+			f.Close()
+		}()
+		recorder = f
+	}
+
+	overflowPolicy, err := eventwatcher.ParseOverflowPolicy(config.IndexerSubscriberOverflow)
+	if err != nil {
+		return nil, err
+	}
 	idxr.events, err = eventwatcher.New(eventwatcher.Config{
-		HTTPClient: idxr.httpClient,
-		Websocket:  idxr.wsClient,
-		LogRange:   config.IndexerMaxLogRange,
-		Addresses:  contractAddrs,
+		HTTPClient:           idxr.httpClient,
+		Websocket:            idxr.wsClient,
+		EpochBlock:           epochBlock,
+		LogRange:             config.IndexerMaxLogRange,
+		Addresses:            contractAddrs,
+		ReorgDepth:           config.IndexerReorgDepth,
+		Concurrency:          config.IndexerMaxConcurrency,
+		PollInterval:         time.Duration(config.IndexerPollIntervalMilliseconds) * time.Millisecond,
+		MaxWebsocketFailures: config.IndexerMaxWebsocketFailures,
+		Confirmations:        config.IndexerConfirmations,
+		FinalizedTag:         config.IndexerFinalizedTag,
+		SubscriberBuffer:     config.IndexerSubscriberBuffer,
+		OverflowPolicy:       overflowPolicy,
+		Replay:               replay,
+		Recorder:             recorder,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	// index cog games, dispatchers, state
+	cogConfig := cog.Config{
+		ReorgDepth:               config.IndexerReorgDepth,
+		StateHistoryBlocks:       config.IndexerStateHistoryBlocks,
+		SessionExpiryGraceBlocks: config.IndexerSessionExpiryGraceBlocks,
+		GameAddress:              config.IndexerGameAddress,
+	}
 	idxr.gameStore, err = cog.NewGameStore(
 		ctx,
 		idxr.httpClient,
 		idxr.events,
+		cogConfig,
 	)
 	if err != nil {
 		return nil, err
@@ -100,31 +216,256 @@ func NewMemoryIndexer(ctx context.Context, notifications chan interface{}, httpP
 		ctx,
 		idxr.events,
 		notifications,
+		cogConfig,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	// start listening for SessionCreate events
+	// start listening for SessionCreate/SessionDestroy events
 	idxr.sessionStore, err = cog.NewSessionStore(
 		ctx,
 		idxr.events,
+		notifications,
+		cogConfig,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	// start any custom stores registered with stores.Register
+	idxr.customStores, err = stores.New(ctx, stores.Config{
+		HTTPClient:    idxr.httpClient,
+		Watcher:       idxr.events,
+		Notifications: notifications,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for name, store := range idxr.customStores {
+		idxr.storesReady = append(idxr.storesReady, stores.Run(ctx, name, store, idxr.events))
+		if _, ok := store.(stores.Checkpointer); !ok && idxr.checkpoints != nil {
+			idxr.log.Warn().
+				Str("store", name).
+				Msg("checkpoints-disabled-unsupported-store")
+			idxr.checkpoints = nil
+		}
+	}
+
 	// index config data
 	idxr.configStore = configstore.New()
 
+	// restore store contents from the checkpoint
+	if cp != nil {
+		if err := idxr.restore(cp); err != nil {
+			return nil, err
+		}
+	}
+
 	// start event collection
 	idxr.events.Start(ctx)
+	go idxr.waitReady(ctx)
+
+	// periodically save the store contents
+	if idxr.checkpoints != nil {
+		go idxr.checkpointLoop(ctx)
+	}
 
 	return idxr, nil
 }
 
+// usable returns cp, or nil if it cannot be restored into this indexer
+func (idxr *MemoryIndexer) usable(cp *checkpoint.Checkpoint, kind string) *checkpoint.Checkpoint {
+	if cp == nil {
+		return nil
+	}
+	if cp.ChainID != idxr.chainID {
+		idxr.log.Warn().
+			Uint64(kind, cp.ChainID).
+			Uint64("chain", idxr.chainID).
+			Msgf("ignoring-%s-chain-mismatch", kind)
+		return nil
+	}
+	// a custom store added since the checkpoint was taken would miss
+	// everything before it, so start again from the beginning
+	for _, name := range stores.Registered() {
+		if _, ok := cp.Stores[name]; !ok {
+			idxr.log.Warn().
+				Str("store", name).
+				Msgf("ignoring-%s-missing-store", kind)
+			return nil
+		}
+	}
+	return cp
+}
+
+func (idxr *MemoryIndexer) restore(cp *checkpoint.Checkpoint) error {
+	if err := idxr.gameStore.Restore(cp); err != nil {
+		return err
+	}
+	if err := idxr.stateStore.Restore(cp); err != nil {
+		return err
+	}
+	if err := idxr.sessionStore.Restore(cp); err != nil {
+		return err
+	}
+	for name, store := range idxr.customStores {
+		checkpointer, ok := store.(stores.Checkpointer)
+		if !ok {
+			return fmt.Errorf("checkpoint: store %v does not support checkpoints", name)
+		}
+		if err := checkpointer.Restore(cp.Block, cp.Stores[name]); err != nil {
+			return fmt.Errorf("checkpoint: failed to restore %v: %v", name, err)
+		}
+	}
+	idxr.log.Info().Int64("block", cp.Block).Msg("restored")
+	return nil
+}
+
+func (idxr *MemoryIndexer) checkpointLoop(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(config.IndexerCheckpointIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	lastBlock := int64(-1)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			idxr.resyncLock.Lock()
+			if idxr.resynced {
+				idxr.resyncLock.Unlock()
+				return
+			}
+			block, err := idxr.checkpoint(lastBlock)
+			idxr.resyncLock.Unlock()
+			if err != nil {
+				idxr.log.Error().Err(err).Msg("checkpoint-fail")
+				continue
+			}
+			lastBlock = block
+		}
+	}
+}
+
+// checkpoint saves the contents of the stores as of the most recent block
+// that all of them have processed and that can no longer be reorged
+func (idxr *MemoryIndexer) checkpoint(lastBlock int64) (int64, error) {
+	cp, ok := idxr.capture(lastBlock)
+	if !ok {
+		return lastBlock, nil
+	}
+	if err := idxr.checkpoints.Save(cp); err != nil {
+		return lastBlock, err
+	}
+	idxr.log.Info().Int64("block", cp.Block).Msg("saved")
+	return cp.Block, nil
+}
+
+// capture copies the contents of the stores as of the most recent block that
+// all of them have processed and that can no longer be reorged, ok is false
+// if that is not after lastBlock or a store has since moved too far ahead to
+// copy it
+func (idxr *MemoryIndexer) capture(lastBlock int64) (cp *checkpoint.Checkpoint, ok bool) {
+	block := idxr.gameStore.LastBlock()
+	if b := idxr.stateStore.LastBlock(); b < block {
+		block = b
+	}
+	if b := idxr.sessionStore.LastBlock(); b < block {
+		block = b
+	}
+	// checkpoints are only enabled when every custom store is a Checkpointer
+	for _, store := range idxr.customStores {
+		if b := store.(stores.Checkpointer).LastBlock(); b < block {
+			block = b
+		}
+	}
+	// a restored checkpoint only holds a single snapshot so a reorg of
+	// anything before it could never be detected, stay behind both the
+	// finalized block and the reorg window
+	block -= int64(config.IndexerReorgDepth)
+	if finalized := idxr.events.Finalized(); finalized < block {
+		block = finalized
+	}
+	if block < 0 || block <= lastBlock {
+		return nil, false
+	}
+	cp = &checkpoint.Checkpoint{
+		ChainID: idxr.chainID,
+		Block:   block,
+	}
+	if !idxr.gameStore.Checkpoint(block, cp) ||
+		!idxr.stateStore.Checkpoint(block, cp) ||
+		!idxr.sessionStore.Checkpoint(block, cp) {
+		// a store has moved too far ahead, try again next time
+		return nil, false
+	}
+	cp.Stores = map[string]json.RawMessage{}
+	for name, store := range idxr.customStores {
+		data, ok := store.(stores.Checkpointer).Checkpoint(block)
+		if !ok {
+			return nil, false
+		}
+		cp.Stores[name] = data
+	}
+	return cp, true
+}
+
+// Snapshot copies the contents of every store for export, see
+// checkpoint.WriteSnapshot
+func (idxr *MemoryIndexer) Snapshot() (*checkpoint.Checkpoint, error) {
+	for name, store := range idxr.customStores {
+		if _, ok := store.(stores.Checkpointer); !ok {
+			return nil, fmt.Errorf("snapshot: store %v does not support checkpoints", name)
+		}
+	}
+	// stores can move past each other's history while being copied
+	for attempt := 0; attempt < 3; attempt++ {
+		if cp, ok := idxr.capture(-1); ok {
+			return cp, nil
+		}
+	}
+	return nil, fmt.Errorf("snapshot: nothing indexed yet or stores too far apart")
+}
+
+// Resync discards everything indexed and indexes the chain again from the
+// first block, for when a dev chain has been reset. The stores cannot be
+// told apart from the old chain while the resync is in progress, so
+// checkpoints are removed and disabled from then on.
+func (idxr *MemoryIndexer) Resync() error {
+	idxr.resyncLock.Lock()
+	defer idxr.resyncLock.Unlock()
+	if idxr.checkpoints != nil && !idxr.resynced {
+		if err := idxr.checkpoints.Clear(); err != nil {
+			return err
+		}
+		idxr.log.Warn().Msg("checkpoints-disabled-after-resync")
+	}
+	idxr.resynced = true
+	idxr.events.Resync()
+	return nil
+}
+
+// Ready is closed once the watcher has caught up with the chain and every
+// custom store has processed the batches it published while doing so
 func (idxr *MemoryIndexer) Ready() chan struct{} {
-	return idxr.events.Ready()
+	return idxr.ready
+}
+
+// Failed is sent the error that stopped the indexer before it became ready,
+// which only happens when replaying a stream that cannot be read to the end
+func (idxr *MemoryIndexer) Failed() <-chan error {
+	return idxr.events.Failed()
+}
+
+func (idxr *MemoryIndexer) waitReady(ctx context.Context) {
+	for _, ready := range append([]<-chan struct{}{idxr.events.Ready()}, idxr.storesReady...) {
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			return
+		}
+	}
+	close(idxr.ready)
 }
 
 func (idxr *MemoryIndexer) GetGame(id string) *model.Game {
@@ -135,19 +476,37 @@ func (idxr *MemoryIndexer) GetGames() []*model.Game {
 	return idxr.gameStore.GetGames()
 }
 
-func (idxr *MemoryIndexer) AddPendingOpSet(estimatedBlockNumber int, opset cog.OpSet) {
-	idxr.stateStore.AddPendingOpSet(estimatedBlockNumber, opset)
+// QueryStore returns data from a custom store registered with stores.Register
+func (idxr *MemoryIndexer) QueryStore(ctx context.Context, name string, key *string) (interface{}, error) {
+	store, ok := idxr.customStores[name]
+	if !ok {
+		return nil, fmt.Errorf("no store found with name %v", name)
+	}
+	return store.Query(ctx, key)
 }
 
-func (idxr *MemoryIndexer) RemovePendingOpSets(opset map[string]bool) {
-	idxr.stateStore.RemovePendingOpSets(opset)
+func (idxr *MemoryIndexer) AddPendingOpSet(stateContractAddr common.Address, estimatedBlockNumber int, opset cog.OpSet) {
+	idxr.stateStore.AddPendingOpSet(stateContractAddr, estimatedBlockNumber, opset)
 }
 
-func (idxr *MemoryIndexer) GetGraph(stateContractAddr common.Address, block int, simulated bool) *model.Graph {
+func (idxr *MemoryIndexer) RemovePendingOpSets(stateContractAddr common.Address, opset map[string]bool) {
+	idxr.stateStore.RemovePendingOpSets(stateContractAddr, opset)
+}
+
+// GetGraph returns the state for the given state contract. If block is
+// non-zero the state as of the end of that block is returned instead of the
+// latest.
+func (idxr *MemoryIndexer) GetGraph(stateContractAddr common.Address, block int, simulated bool, finalized bool) (*model.Graph, error) {
+	if block > 0 {
+		return idxr.stateStore.GetGraphAt(stateContractAddr, int64(block))
+	}
+	if finalized {
+		return idxr.stateStore.GetFinalizedGraph(stateContractAddr), nil
+	}
 	if simulated {
-		return idxr.stateStore.GetPendingGraph()
+		return idxr.stateStore.GetPendingGraph(stateContractAddr), nil
 	}
-	return idxr.stateStore.GetGraph()
+	return idxr.stateStore.GetGraph(stateContractAddr), nil
 }
 func (idxr *MemoryIndexer) GetSession(routerAddr common.Address, sessionID string) *model.Session {
 	return idxr.sessionStore.GetSession(routerAddr, sessionID)
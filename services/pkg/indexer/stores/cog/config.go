@@ -0,0 +1,18 @@
+package cog
+
+import "github.com/ethereum/go-ethereum/common"
+
+// Config is the configuration shared by the cog stores
+type Config struct {
+	// ReorgDepth is how many blocks back a chain reorg may reach, the
+	// stores keep enough history to rewind that far
+	ReorgDepth int
+	// StateHistoryBlocks is how many blocks of state graphs to keep for
+	// historical queries, at least ReorgDepth are always kept
+	StateHistoryBlocks int
+	// SessionExpiryGraceBlocks is how many blocks an expired session is
+	// kept before it is pruned
+	SessionExpiryGraceBlocks int
+	// GameAddress, if set, is the only game that is indexed
+	GameAddress common.Address
+}
@@ -12,8 +12,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/playmint/ds-node/pkg/api/model"
 	"github.com/playmint/ds-node/pkg/client/alchemy"
-	"github.com/playmint/ds-node/pkg/config"
 	"github.com/playmint/ds-node/pkg/contracts/game"
+	"github.com/playmint/ds-node/pkg/indexer/checkpoint"
 	"github.com/playmint/ds-node/pkg/indexer/eventwatcher"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -24,15 +24,23 @@ const LATEST = "latest"
 type GameStore struct {
 	games        *immutable.Map[string, *model.Game]
 	latest       *model.Game
-	latestByName map[string]*model.Game
+	latestByName *immutable.Map[string, *model.Game]
 	abi          *abi.ABI
 	events       *eventwatcher.Watcher
 	client       *alchemy.Client
 	log          zerolog.Logger
+	history      *snapshots[gameSnapshot]
+	config       Config
 	sync.RWMutex
 }
 
-func NewGameStore(ctx context.Context, client *alchemy.Client, watcher *eventwatcher.Watcher) (*GameStore, error) {
+type gameSnapshot struct {
+	games        *immutable.Map[string, *model.Game]
+	latest       *model.Game
+	latestByName *immutable.Map[string, *model.Game]
+}
+
+func NewGameStore(ctx context.Context, client *alchemy.Client, watcher *eventwatcher.Watcher, cfg Config) (*GameStore, error) {
 	cabi, err := abi.JSON(strings.NewReader(game.BaseGameABI))
 	if err != nil {
 		return nil, err
@@ -43,7 +51,9 @@ func NewGameStore(ctx context.Context, client *alchemy.Client, watcher *eventwat
 		events:       watcher,
 		games:        immutable.NewMap[string, *model.Game](nil),
 		log:          log.With().Str("service", "indexer").Str("component", "gamestore").Logger(),
-		latestByName: map[string]*model.Game{},
+		latestByName: immutable.NewMap[string, *model.Game](nil),
+		history:      newSnapshots[gameSnapshot](cfg.ReorgDepth),
+		config:       cfg,
 	}
 
 	// watch all events from all contracts that match the GameDeployed topic
@@ -52,22 +62,52 @@ func NewGameStore(ctx context.Context, client *alchemy.Client, watcher *eventwat
 	if err != nil {
 		return nil, err
 	}
-	queue := watcher.SubscribeTopic(topics[0])
+	queue := watcher.SubscribeTopic("games", topics[0])
 
-	go store.watch(ctx, queue)
+	// when only watching some contracts, extend the set with the
+	// contracts belonging to each game as they are deployed
+	watcher.AddDiscoverer(eventwatcher.Discoverer{
+		Topics:   topics[0],
+		Discover: store.discover,
+	})
+
+	go store.watch(ctx, queue.C())
 	return store, nil
 }
 
+// discover returns the contracts that make up the game announced by a
+// GameDeployed log
+func (rs *GameStore) discover(rawEvent types.Log) []common.Address {
+	if rs.config.GameAddress != common.HexToAddress("") && rawEvent.Address != rs.config.GameAddress {
+		return nil
+	}
+	var evt game.BaseGameGameDeployed
+	if err := unpackLog(rs.abi, &evt, "GameDeployed", rawEvent); err != nil {
+		rs.log.Warn().Err(err).Msgf("undecodable %T event", evt)
+		return nil
+	}
+	return []common.Address{
+		evt.StateAddr,
+		evt.RouterAddr,
+		evt.DispatcherAddr,
+	}
+}
+
 func (rs *GameStore) Fork(ctx context.Context, watcher *eventwatcher.Watcher, client *alchemy.Client) *GameStore {
 	return rs
 }
 
-func (rs *GameStore) watch(ctx context.Context, blocks chan *eventwatcher.LogBatch) {
+func (rs *GameStore) watch(ctx context.Context, blocks <-chan *eventwatcher.LogBatch) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case block := <-blocks:
+			if block.Reset {
+				rs.clear()
+			} else if rewindBlock, ok := block.Rewind(); ok {
+				rs.rewind(rewindBlock)
+			}
 			for _, rawEvent := range block.Logs {
 				eventABI, err := rs.abi.EventByID(rawEvent.Topics[0])
 				if err != nil {
@@ -90,36 +130,79 @@ func (rs *GameStore) watch(ctx context.Context, blocks chan *eventwatcher.LogBat
 					rs.log.Warn().Msgf("ignoring unhandled event type %v", eventABI)
 				}
 			}
+			rs.Lock()
+			rs.history.push(block.ToBlock, gameSnapshot{
+				games:        rs.games,
+				latest:       rs.latest,
+				latestByName: rs.latestByName,
+			})
+			rs.Unlock()
 		}
 	}
 }
 
+// clear discards every game, for when the chain has been reset
+func (rs *GameStore) clear() {
+	rs.Lock()
+	defer rs.Unlock()
+
+	rs.games = immutable.NewMap[string, *model.Game](nil)
+	rs.latest = nil
+	rs.latestByName = immutable.NewMap[string, *model.Game](nil)
+	rs.history = newSnapshots[gameSnapshot](rs.config.ReorgDepth)
+	rs.log.Warn().Msg("reset")
+}
+
+// rewind restores the games as they were at the end of the given block
+func (rs *GameStore) rewind(block int64) {
+	rs.Lock()
+	defer rs.Unlock()
+
+	snapshot, _, ok := rs.history.rewind(block)
+	if !ok {
+		rs.log.Error().
+			Int64("block", block).
+			Msg("reorg-deeper-than-history")
+		return
+	}
+	if snapshot.games == nil {
+		snapshot.games = immutable.NewMap[string, *model.Game](nil)
+		snapshot.latestByName = immutable.NewMap[string, *model.Game](nil)
+	}
+	rs.games = snapshot.games
+	rs.latest = snapshot.latest
+	rs.latestByName = snapshot.latestByName
+}
+
 // An action was registered, update the mapping for the game
 func (rs *GameStore) setGame(evt *game.BaseGameGameDeployed) error {
 	rs.Lock()
 	defer rs.Unlock()
 
 	if evt.Raw.Removed {
-		// hmmm blockchain reorg occured so what do we do?
-		// just ignore for now, but this probably needs more thought
+		// removed logs are superseded by the reorg batch
 		return nil
 	}
 
 	// if we are configured to only index a single game id
 	// then ignore any others
-	if config.IndexerGameAddress != common.HexToAddress("") && evt.Raw.Address != config.IndexerGameAddress {
-		rs.log.Warn().Msgf("ignoring game %s as we are configued to index %s only", evt.Raw.Address.Hex(), config.IndexerGameAddress.Hex())
+	if rs.config.GameAddress != common.HexToAddress("") && evt.Raw.Address != rs.config.GameAddress {
+		rs.log.Warn().Msgf("ignoring game %s as we are configued to index %s only", evt.Raw.Address.Hex(), rs.config.GameAddress.Hex())
 		return nil
 	}
 
-	// fetch the metadata
-	gameContract, err := game.NewBaseGame(evt.Raw.Address, rs.client)
-	if err != nil {
-		return err
-	}
-	meta, err := gameContract.GetMetadata(nil)
-	if err != nil {
-		return err
+	// fetch the metadata, without a client (such as when replaying
+	// offline) the game has no name or url
+	var meta game.GameMetadata
+	if rs.client != nil {
+		gameContract, err := game.NewBaseGame(evt.Raw.Address, rs.client)
+		if err != nil {
+			return err
+		}
+		meta, err = gameContract.GetMetadata(nil)
+		if err != nil {
+			return err
+		}
 	}
 
 	// create new game object
@@ -138,8 +221,83 @@ func (rs *GameStore) setGame(evt *game.BaseGameGameDeployed) error {
 	// update the "LATEST" tag, handy in local development
 	// TODO: probably disable this from config as it's a bit weird in prod
 	rs.latest = game
-	rs.latestByName[meta.Name] = game
+	if meta.Name != "" {
+		rs.latestByName = rs.latestByName.Set(meta.Name, game)
+	}
+
+	return nil
+}
+
+// LastBlock returns the last block processed by the store or -1 if none
+func (rs *GameStore) LastBlock() int64 {
+	rs.RLock()
+	defer rs.RUnlock()
+	return rs.history.latest()
+}
+
+// Checkpoint fills cp with the games as they were at the end of block, it
+// returns false if the store no longer holds the state for that block
+func (rs *GameStore) Checkpoint(block int64, cp *checkpoint.Checkpoint) bool {
+	rs.RLock()
+	snapshot, _, ok := rs.history.before(block)
+	evicted := rs.history.evicted
+	rs.RUnlock()
+	if !ok && evicted {
+		return false
+	}
+	cp.Games = []*model.Game{}
+	cp.LatestByName = map[string]string{}
+	cp.Latest = ""
+	if snapshot.games == nil {
+		return true
+	}
+	itr := snapshot.games.Iterator()
+	for !itr.Done() {
+		_, game, _ := itr.Next()
+		cp.Games = append(cp.Games, game)
+	}
+	if snapshot.latest != nil {
+		cp.Latest = snapshot.latest.ID
+	}
+	namesItr := snapshot.latestByName.Iterator()
+	for !namesItr.Done() {
+		name, game, _ := namesItr.Next()
+		cp.LatestByName[name] = game.ID
+	}
+	return true
+}
+
+// Restore replaces the games with those from a checkpoint, it must be
+// called before the watcher is started
+func (rs *GameStore) Restore(cp *checkpoint.Checkpoint) error {
+	rs.Lock()
+	defer rs.Unlock()
+	games := immutable.NewMap[string, *model.Game](nil)
+	for _, game := range cp.Games {
+		games = games.Set(game.ID, game)
+	}
+	latestByName := immutable.NewMap[string, *model.Game](nil)
+	for name, id := range cp.LatestByName {
+		game, ok := games.Get(id)
+		if !ok {
+			return fmt.Errorf("checkpoint: missing game %v for name %v", id, name)
+		}
+		latestByName = latestByName.Set(name, game)
+	}
+	rs.games = games
+	rs.latest, _ = games.Get(cp.Latest)
+	rs.latestByName = latestByName
+
+	// keep watching the contracts of games discovered before the checkpoint
+	for _, game := range cp.Games {
+		rs.events.WatchAddresses(game.StateAddress, game.RouterAddress, game.DispatcherAddress)
+	}
 
+	rs.history.reset(cp.Block, gameSnapshot{
+		games:        rs.games,
+		latest:       rs.latest,
+		latestByName: rs.latestByName,
+	})
 	return nil
 }
 
@@ -154,7 +312,7 @@ func (rs *GameStore) GetGame(id string) *model.Game {
 	// fetch latest deployment by name
 	// this is only for local-dev convinence
 	// TODO: disable this when in production mode
-	if game, ok := rs.latestByName[id]; ok {
+	if game, ok := rs.latestByName.Get(id); ok {
 		return game
 	}
 
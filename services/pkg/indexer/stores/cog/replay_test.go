@@ -0,0 +1,104 @@
+package cog
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/playmint/ds-node/pkg/contracts/game"
+	"github.com/playmint/ds-node/pkg/contracts/router"
+	"github.com/playmint/ds-node/pkg/indexer/eventwatcher"
+)
+
+// TestReplayOffline indexes a recording without any provider, so games are
+// indexed without their metadata
+func TestReplayOffline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gameABI, err := abi.JSON(strings.NewReader(game.BaseGameABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	routerABI, err := abi.JSON(strings.NewReader(router.SessionRouterABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gameAddr := common.HexToAddress("0x9fe46736679d2d9a65f0992f2272de9f3c7fa6e0")
+	dispatcherAddr := common.HexToAddress("0xcf7ed3acca5a467e9e704c703e8d87f634fb0fc9")
+	routerAddr := common.HexToAddress("0xdc64a140aa3e981100a9beca4e685f962f0cf6c9")
+	session := common.HexToAddress("0x70997970c51812dc3a010c7d01b50e0d17dc79c8")
+	owner := common.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	var id [24]byte
+	id[0] = 0x01
+
+	stateStore := newTestStateStore(t)
+	deployed := makeLog(t, &gameABI, "GameDeployed", 1, dispatcherAddr, testStateAddr, routerAddr)
+	deployed.Address = gameAddr
+	created := makeLog(t, &routerABI, "SessionCreate", 2, session, owner, uint32(100), uint32(0))
+	created.Address = routerAddr
+	stream := []string{}
+	for _, log := range []types.Log{deployed, created, dataSetLog(t, stateStore.abi, 3, id, "hp", 1)} {
+		b, err := json.Marshal(log)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream = append(stream, string(b))
+	}
+
+	watcher, err := eventwatcher.New(eventwatcher.Config{
+		LogRange: 1,
+		Replay:   strings.NewReader(strings.Join(stream, "\n")),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{ReorgDepth: 64, StateHistoryBlocks: 256, SessionExpiryGraceBlocks: 300}
+	notifications := make(chan interface{}, 64)
+	games, err := NewGameStore(ctx, nil, watcher, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	states, err := NewStateStore(ctx, watcher, notifications, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessions, err := NewSessionStore(ctx, watcher, notifications, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	watcher.Start(ctx)
+	select {
+	case <-watcher.Ready():
+	case err := <-watcher.Failed():
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("replay never became ready")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for games.LastBlock() < 1 || sessions.LastBlock() < 2 || states.LastBlock() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatal("stores never caught up with the replay")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	g := games.GetGame(gameAddr.Hex())
+	if g == nil {
+		t.Fatalf("game %s not indexed", gameAddr.Hex())
+	}
+	if g.Name != "" || g.StateAddress != testStateAddr || g.RouterAddress != routerAddr {
+		t.Errorf("got game %+v", g)
+	}
+	if sessions.GetSession(routerAddr, session.Hex()) == nil {
+		t.Errorf("session %s not indexed", session.Hex())
+	}
+	if got := hp(t, states.GetGraph(testStateAddr), hexutil.Encode(id[:])); got != 1 {
+		t.Errorf("hp = %v, want 1", got)
+	}
+}
@@ -10,51 +10,68 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/playmint/ds-node/pkg/api/model"
 	"github.com/playmint/ds-node/pkg/contracts/router"
+	"github.com/playmint/ds-node/pkg/indexer/checkpoint"
 	"github.com/playmint/ds-node/pkg/indexer/eventwatcher"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
-var FULL_ACCESS uint32 = 0xffffffff
-
 type SessionStore struct {
-	sessions *immutable.Map[string, *immutable.Map[string, *model.Session]]
-	abi      *abi.ABI
-	events   *eventwatcher.Watcher
-	log      zerolog.Logger
+	sessions      *immutable.Map[string, *immutable.Map[string, *model.Session]]
+	abi           *abi.ABI
+	events        *eventwatcher.Watcher
+	log           zerolog.Logger
+	history       *snapshots[*immutable.Map[string, *immutable.Map[string, *model.Session]]]
+	notifications chan interface{}
+	head          int64
+	config        Config
 	sync.RWMutex
 }
 
-func NewSessionStore(ctx context.Context, watcher *eventwatcher.Watcher) (*SessionStore, error) {
+func NewSessionStore(ctx context.Context, watcher *eventwatcher.Watcher, notifications chan interface{}, cfg Config) (*SessionStore, error) {
 	cabi, err := abi.JSON(strings.NewReader(router.SessionRouterABI))
 	if err != nil {
 		return nil, err
 	}
 	store := &SessionStore{
-		abi:      &cabi,
-		events:   watcher,
-		sessions: immutable.NewMap[string, *immutable.Map[string, *model.Session]](nil),
-		log:      log.With().Str("service", "indexer").Str("component", "sessionstore").Logger(),
+		abi:           &cabi,
+		events:        watcher,
+		sessions:      immutable.NewMap[string, *immutable.Map[string, *model.Session]](nil),
+		log:           log.With().Str("service", "indexer").Str("component", "sessionstore").Logger(),
+		history:       newSnapshots[*immutable.Map[string, *immutable.Map[string, *model.Session]]](cfg.ReorgDepth),
+		notifications: notifications,
+		head:          -1,
+		config:        cfg,
 	}
 
-	// watch all events from all contracts that match the SessionCreate topic
-	query := [][]interface{}{{cabi.Events["SessionCreate"].ID}}
+	// watch all events from all contracts that match the SessionCreate or
+	// SessionDestroy topics
+	query := [][]interface{}{{
+		cabi.Events["SessionCreate"].ID,
+		cabi.Events["SessionDestroy"].ID,
+	}}
 	topics, err := abi.MakeTopics(query...)
 	if err != nil {
 		return nil, err
 	}
-	queue := watcher.SubscribeTopic(topics[0])
+	queue := watcher.SubscribeTopic("sessions", topics[0])
 
-	go store.watch(ctx, queue)
+	go store.watch(ctx, queue.C())
 	return store, nil
 }
 
-func (rs *SessionStore) watch(ctx context.Context, blocks chan *eventwatcher.LogBatch) {
+func (rs *SessionStore) watch(ctx context.Context, blocks <-chan *eventwatcher.LogBatch) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case block := <-blocks:
+			if block.Reset {
+				rs.clear()
+			} else if rewindBlock, ok := block.Rewind(); ok {
+				rs.rewind(rewindBlock)
+			}
+			changed := []*model.Session{}
 			for _, rawEvent := range block.Logs {
 				eventABI, err := rs.abi.EventByID(rawEvent.Topics[0])
 				if err != nil {
@@ -70,9 +87,24 @@ func (rs *SessionStore) watch(ctx context.Context, blocks chan *eventwatcher.Log
 						continue
 					}
 					evt.Raw = rawEvent
-					err := rs.setSession(&evt)
+					session, err := rs.setSession(&evt)
+					if err != nil {
+						rs.log.Error().Err(err).Msgf("failed process %T event", evt)
+					} else if session != nil {
+						changed = append(changed, session)
+					}
+				case "SessionDestroy":
+					var evt router.SessionRouterSessionDestroy
+					if err := unpackLog(rs.abi, &evt, eventABI.RawName, rawEvent); err != nil {
+						rs.log.Warn().Err(err).Msgf("undecodable %T event", evt)
+						continue
+					}
+					evt.Raw = rawEvent
+					session, err := rs.removeSession(&evt)
 					if err != nil {
 						rs.log.Error().Err(err).Msgf("failed process %T event", evt)
+					} else if session != nil {
+						changed = append(changed, session)
 					}
 				case "SeenOpSet":
 					// noop
@@ -80,28 +112,132 @@ func (rs *SessionStore) watch(ctx context.Context, blocks chan *eventwatcher.Log
 					rs.log.Warn().Msgf("ignoring unhandled event type %v", eventABI)
 				}
 			}
+			caughtUp := rs.caughtUp(block)
+			rs.Lock()
+			expired := rs.expireSessions(block.ToBlock)
+			if caughtUp {
+				// expiries found while catching up are history, nobody
+				// is waiting to hear about them
+				changed = append(changed, expired...)
+			}
+			rs.history.push(block.ToBlock, rs.sessions)
+			for i, session := range changed {
+				changed[i] = rs.withStatus(session)
+			}
+			rs.Unlock()
+
+			for _, session := range changed {
+				rs.notifications <- session
+			}
+		}
+	}
+}
+
+// caughtUp reports whether block was published after the watcher's initial
+// catch up with the chain head
+func (rs *SessionStore) caughtUp(block *eventwatcher.LogBatch) bool {
+	select {
+	case <-rs.events.Ready():
+		return block.ToBlock > rs.events.ReadyBlock()
+	default:
+		return false
+	}
+}
+
+// clear discards every session, for when the chain has been reset
+func (rs *SessionStore) clear() {
+	rs.Lock()
+	defer rs.Unlock()
+
+	rs.sessions = immutable.NewMap[string, *immutable.Map[string, *model.Session]](nil)
+	rs.history = newSnapshots[*immutable.Map[string, *immutable.Map[string, *model.Session]]](rs.config.ReorgDepth)
+	rs.head = -1
+	rs.log.Warn().Msg("reset")
+}
+
+// rewind restores the sessions as they were at the end of the given block
+func (rs *SessionStore) rewind(block int64) {
+	rs.Lock()
+	defer rs.Unlock()
+
+	sessions, _, ok := rs.history.rewind(block)
+	if !ok {
+		rs.log.Error().
+			Int64("block", block).
+			Msg("reorg-deeper-than-history")
+		return
+	}
+	if sessions == nil {
+		sessions = immutable.NewMap[string, *immutable.Map[string, *model.Session]](nil)
+	}
+	rs.sessions = sessions
+	rs.head = block
+}
+
+// expireSessions moves the head to the given block, it returns the sessions
+// that expired since the previous head and prunes any that expired more
+// than the grace period ago. must be called with the lock held.
+func (rs *SessionStore) expireSessions(head int64) []*model.Session {
+	expired := []*model.Session{}
+	grace := int64(rs.config.SessionExpiryGraceBlocks)
+	sessionsByRouter := rs.sessions
+	routerItr := rs.sessions.Iterator()
+	for !routerItr.Done() {
+		routerAddr, sessions, _ := routerItr.Next()
+		pruned := sessions
+		itr := sessions.Iterator()
+		for !itr.Done() {
+			id, session, _ := itr.Next()
+			exp := int64(session.Expires)
+			if exp >= head {
+				continue // still active
+			}
+			if exp >= rs.head {
+				expired = append(expired, session)
+			}
+			if exp+grace < head {
+				pruned = pruned.Delete(id)
+			}
+		}
+		if pruned != sessions {
+			sessionsByRouter = sessionsByRouter.Set(routerAddr, pruned)
 		}
 	}
+	rs.sessions = sessionsByRouter
+	rs.head = head
+	return expired
 }
 
-// An action was registered, update the mapping for the game
-func (rs *SessionStore) setSession(evt *router.SessionRouterSessionCreate) error {
+// withStatus returns a copy of session with its status as of the current
+// head. must be called with the lock held.
+func (rs *SessionStore) withStatus(session *model.Session) *model.Session {
+	s := *session
+	switch {
+	case s.Revoked:
+		s.Status = model.SessionStatusRevoked
+	case rs.head > int64(s.Expires):
+		s.Status = model.SessionStatusExpired
+	default:
+		s.Status = model.SessionStatusActive
+	}
+	return &s
+}
+
+// A session was created, update the mapping for the router
+func (rs *SessionStore) setSession(evt *router.SessionRouterSessionCreate) (*model.Session, error) {
 	rs.Lock()
 	defer rs.Unlock()
 
 	if evt.Raw.Removed {
-		// hmmm blockchain reorg occured so what do we do?
-		// just ignore for now, but this probably needs more thought
-		return nil
+		// removed logs are superseded by the reorg batch
+		return nil, nil
 	}
 
 	// create new session object
 	session := &model.Session{
-		ID:    evt.Session.Hex(),
-		Owner: evt.Owner.Hex(),
-		Scope: &model.SessionScope{
-			FullAccess: (evt.Scopes & FULL_ACCESS) == FULL_ACCESS,
-		},
+		ID:            evt.Session.Hex(),
+		Owner:         evt.Owner.Hex(),
+		Scopes:        evt.Scopes,
 		Expires:       int(evt.Exp),
 		RouterAddress: evt.Raw.Address.Hex(),
 	}
@@ -116,6 +252,86 @@ func (rs *SessionStore) setSession(evt *router.SessionRouterSessionCreate) error
 	sessions = sessions.Set(evt.Session.Hex(), session)
 	rs.sessions = rs.sessions.Set(evt.Raw.Address.Hex(), sessions)
 
+	return session, nil
+}
+
+// A session was revoked, drop it from the mapping for the router and return
+// it marked as revoked so that subscribers can be told
+func (rs *SessionStore) removeSession(evt *router.SessionRouterSessionDestroy) (*model.Session, error) {
+	rs.Lock()
+	defer rs.Unlock()
+
+	if evt.Raw.Removed {
+		// removed logs are superseded by the reorg batch
+		return nil, nil
+	}
+
+	sessions, sessionsExist := rs.sessions.Get(evt.Raw.Address.Hex())
+	if !sessionsExist {
+		return nil, nil
+	}
+	session, ok := sessions.Get(evt.Session.Hex())
+	if !ok {
+		return nil, nil
+	}
+
+	sessions = sessions.Delete(evt.Session.Hex())
+	rs.sessions = rs.sessions.Set(evt.Raw.Address.Hex(), sessions)
+
+	revoked := *session
+	revoked.Revoked = true
+	return &revoked, nil
+}
+
+// LastBlock returns the last block processed by the store or -1 if none
+func (rs *SessionStore) LastBlock() int64 {
+	rs.RLock()
+	defer rs.RUnlock()
+	return rs.history.latest()
+}
+
+// Checkpoint fills cp with the sessions as they were at the end of block, it
+// returns false if the store no longer holds the state for that block
+func (rs *SessionStore) Checkpoint(block int64, cp *checkpoint.Checkpoint) bool {
+	rs.RLock()
+	sessionsByRouter, _, ok := rs.history.before(block)
+	evicted := rs.history.evicted
+	rs.RUnlock()
+	if !ok && evicted {
+		return false
+	}
+	cp.Sessions = []*model.Session{}
+	if sessionsByRouter == nil {
+		return true
+	}
+	routerItr := sessionsByRouter.Iterator()
+	for !routerItr.Done() {
+		_, sessions, _ := routerItr.Next()
+		itr := sessions.Iterator()
+		for !itr.Done() {
+			_, session, _ := itr.Next()
+			cp.Sessions = append(cp.Sessions, session)
+		}
+	}
+	return true
+}
+
+// Restore replaces the sessions with those from a checkpoint, it must be
+// called before the watcher is started
+func (rs *SessionStore) Restore(cp *checkpoint.Checkpoint) error {
+	rs.Lock()
+	defer rs.Unlock()
+	sessionsByRouter := immutable.NewMap[string, *immutable.Map[string, *model.Session]](nil)
+	for _, session := range cp.Sessions {
+		sessions, ok := sessionsByRouter.Get(session.RouterAddress)
+		if !ok {
+			sessions = immutable.NewMap[string, *model.Session](nil)
+		}
+		sessionsByRouter = sessionsByRouter.Set(session.RouterAddress, sessions.Set(session.ID, session))
+	}
+	rs.sessions = sessionsByRouter
+	rs.head = cp.Block
+	rs.history.reset(cp.Block, sessionsByRouter)
 	return nil
 }
 
@@ -131,7 +347,7 @@ func (rs *SessionStore) GetSession(routerAddr common.Address, sessionID string)
 	if !ok {
 		return nil
 	}
-	return session
+	return rs.withStatus(session)
 }
 
 func (rs *SessionStore) GetSessions(routerAddr common.Address, owner *string) []*model.Session {
@@ -153,7 +369,7 @@ func (rs *SessionStore) GetSessions(routerAddr common.Address, owner *string) []
 		if owner != nil && *owner != session.Owner {
 			continue
 		}
-		sessions = append(sessions, session)
+		sessions = append(sessions, rs.withStatus(session))
 	}
 	return sessions
 }
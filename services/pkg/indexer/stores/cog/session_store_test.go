@@ -0,0 +1,94 @@
+package cog
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/benbjohnson/immutable"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/playmint/ds-node/pkg/api/model"
+	"github.com/playmint/ds-node/pkg/contracts/router"
+	"github.com/playmint/ds-node/pkg/indexer/eventwatcher"
+	"github.com/rs/zerolog"
+)
+
+func TestSessionStoreExpiryNotifications(t *testing.T) {
+	cabi, err := abi.JSON(strings.NewReader(router.SessionRouterABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	session := common.HexToAddress("0x70997970c51812dc3a010c7d01b50e0d17dc79c8")
+	owner := common.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	create := makeLog(t, &cabi, "SessionCreate", 1, session, owner, uint32(5), uint32(0))
+
+	tests := []struct {
+		name string
+		// caughtUp starts the watcher so that the batches arrive after
+		// its initial catch up
+		caughtUp bool
+		want     []model.SessionStatus
+	}{
+		{
+			name: "while catching up",
+			want: []model.SessionStatus{model.SessionStatusActive},
+		},
+		{
+			name:     "once caught up",
+			caughtUp: true,
+			want:     []model.SessionStatus{model.SessionStatusActive, model.SessionStatusExpired},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			watcher, err := eventwatcher.New(eventwatcher.Config{LogRange: 1, Replay: strings.NewReader("")})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.caughtUp {
+				watcher.Start(ctx)
+				<-watcher.Ready()
+			}
+			rs := &SessionStore{
+				abi:           &cabi,
+				events:        watcher,
+				sessions:      immutable.NewMap[string, *immutable.Map[string, *model.Session]](nil),
+				log:           zerolog.Nop(),
+				history:       newSnapshots[*immutable.Map[string, *immutable.Map[string, *model.Session]]](64),
+				notifications: make(chan interface{}, 16),
+				head:          -1,
+				config:        Config{SessionExpiryGraceBlocks: 300},
+			}
+			blocks := make(chan *eventwatcher.LogBatch)
+			go rs.watch(ctx, blocks)
+			blocks <- &eventwatcher.LogBatch{
+				EventBatch: eventwatcher.EventBatch{FromBlock: 0, ToBlock: 1},
+				Logs:       []types.Log{create},
+			}
+			blocks <- &eventwatcher.LogBatch{
+				EventBatch: eventwatcher.EventBatch{FromBlock: 2, ToBlock: 10},
+			}
+			// the store has finished with a batch once it takes the next
+			blocks <- &eventwatcher.LogBatch{
+				EventBatch: eventwatcher.EventBatch{FromBlock: 11, ToBlock: 11},
+			}
+
+			got := []model.SessionStatus{}
+			for len(rs.notifications) > 0 {
+				got = append(got, (<-rs.notifications).(*model.Session).Status)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got notifications %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got notifications %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package cog
+
+// snapshots is a short history of immutable store state keyed by the block
+// number it was taken at. Since the stores only ever hold immutable maps, keeping
+// a snapshot is just keeping a pointer, which makes rewinding after a chain
+// reorg cheap.
+type snapshots[T any] struct {
+	// depth is how many blocks behind the latest snapshot the history must
+	// be able to answer for
+	depth int
+	// limit is the most snapshots ever held, past it the oldest is evicted
+	// even if it is the finalized snapshot
+	limit   int
+	blocks  []int64
+	values  []T
+	evicted bool
+	// finalized is the most recent block that can no longer be reorged, the
+	// newest snapshot at or before it is never evicted so that it can always
+	// be served as the finalized state. -1 if unknown.
+	finalized int64
+}
+
+func newSnapshots[T any](depth int) *snapshots[T] {
+	if depth < 1 {
+		depth = 1
+	}
+	return &snapshots[T]{
+		depth: depth,
+		// one snapshot for every block in the window, plus as many again
+		// for a finalized block that lags behind it
+		limit:     2*depth + 1,
+		finalized: -1,
+	}
+}
+
+// push records the state as of the end of block
+func (s *snapshots[T]) push(block int64, value T) {
+	// a batch for the same block replaces the previous snapshot
+	if n := len(s.blocks); n > 0 && s.blocks[n-1] >= block {
+		s.rewind(block - 1)
+	}
+	s.blocks = append(s.blocks, block)
+	s.values = append(s.values, value)
+	s.evict()
+}
+
+// evict drops snapshots that are no longer needed to answer for any block
+// within depth blocks of the latest one, and the oldest snapshots beyond
+// limit so that a stalled finalized block cannot grow the history forever
+func (s *snapshots[T]) evict() {
+	for len(s.blocks) > s.limit || (len(s.blocks) > 1 && s.blocks[1] <= s.latest()-int64(s.depth)) {
+		if len(s.blocks) <= s.limit && s.finalized >= 0 && s.blocks[1] > s.finalized {
+			break // keep the finalized snapshot
+		}
+		s.blocks = s.blocks[1:]
+		s.values = s.values[1:]
+		s.evicted = true
+	}
+}
+
+// finalize marks every block up to and including block as final
+func (s *snapshots[T]) finalize(block int64) {
+	if block <= s.finalized {
+		return
+	}
+	s.finalized = block
+	s.evict()
+}
+
+// before returns the most recent snapshot taken at or before block
+func (s *snapshots[T]) before(block int64) (value T, at int64, ok bool) {
+	for i := len(s.blocks) - 1; i >= 0; i-- {
+		if s.blocks[i] <= block {
+			return s.values[i], s.blocks[i], true
+		}
+	}
+	return value, -1, false
+}
+
+// rewind discards every snapshot taken after block and returns the most
+// recent one remaining along with the block it was taken at. ok is false if
+// the history does not reach back far enough to rewind to block.
+func (s *snapshots[T]) rewind(block int64) (value T, at int64, ok bool) {
+	i := len(s.blocks)
+	for i > 0 && s.blocks[i-1] > block {
+		i--
+	}
+	s.blocks = s.blocks[:i]
+	s.values = s.values[:i]
+	if i == 0 {
+		// if nothing was ever evicted then rewinding past the first
+		// snapshot is rewinding to the empty state
+		return value, -1, !s.evicted
+	}
+	return s.values[i-1], s.blocks[i-1], true
+}
+
+// at returns the snapshot taken at exactly block
+func (s *snapshots[T]) at(block int64) (value T, ok bool) {
+	for i := len(s.blocks) - 1; i >= 0; i-- {
+		if s.blocks[i] == block {
+			return s.values[i], true
+		}
+	}
+	return value, false
+}
+
+// latest returns the block of the most recent snapshot or -1 if there are none
+func (s *snapshots[T]) latest() int64 {
+	if len(s.blocks) == 0 {
+		return -1
+	}
+	return s.blocks[len(s.blocks)-1]
+}
+
+// reset replaces the history with a single snapshot, anything before it is
+// treated as unreachable
+func (s *snapshots[T]) reset(block int64, value T) {
+	s.blocks = []int64{block}
+	s.values = []T{value}
+	s.evicted = true
+	s.finalized = -1
+}
@@ -0,0 +1,245 @@
+package cog
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// history describes each snapshot as "block:value"
+func history(s *snapshots[int]) []string {
+	descs := []string{}
+	for i, block := range s.blocks {
+		descs = append(descs, fmt.Sprintf("%d:%d", block, s.values[i]))
+	}
+	return descs
+}
+
+// pushAll pushes a snapshot for each block with the block's position in the
+// list as its value
+func pushAll(s *snapshots[int], blocks ...int64) *snapshots[int] {
+	for i, block := range blocks {
+		s.push(block, i)
+	}
+	return s
+}
+
+func TestSnapshotsPush(t *testing.T) {
+	tests := []struct {
+		name      string
+		depth     int
+		finalized int64
+		pushes    []int64
+		want      []string
+	}{
+		{
+			name:      "within depth",
+			depth:     10,
+			finalized: -1,
+			pushes:    []int64{1, 2, 3},
+			want:      []string{"1:0", "2:1", "3:2"},
+		},
+		{
+			name:      "evicts beyond depth",
+			depth:     2,
+			finalized: -1,
+			pushes:    []int64{1, 2, 3, 4, 5},
+			want:      []string{"3:2", "4:3", "5:4"},
+		},
+		{
+			name:      "keeps the snapshot at or before depth",
+			depth:     2,
+			finalized: -1,
+			pushes:    []int64{1, 5, 10},
+			want:      []string{"5:1", "10:2"},
+		},
+		{
+			name:      "depth of at least one",
+			depth:     0,
+			finalized: -1,
+			pushes:    []int64{1, 2, 3},
+			want:      []string{"2:1", "3:2"},
+		},
+		{
+			name:      "keeps the finalized snapshot",
+			depth:     2,
+			finalized: 3,
+			pushes:    []int64{1, 2, 3, 4, 5, 6},
+			want:      []string{"3:2", "4:3", "5:4", "6:5"},
+		},
+		{
+			name:      "caps a lagging finalized snapshot",
+			depth:     1,
+			finalized: 1,
+			pushes:    []int64{1, 2, 3, 4, 5, 6},
+			want:      []string{"4:3", "5:4", "6:5"},
+		},
+		{
+			name:      "same block replaces",
+			depth:     10,
+			finalized: -1,
+			pushes:    []int64{1, 2, 2},
+			want:      []string{"1:0", "2:2"},
+		},
+		{
+			name:      "earlier block replaces everything after it",
+			depth:     10,
+			finalized: -1,
+			pushes:    []int64{1, 2, 3, 2},
+			want:      []string{"1:0", "2:3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSnapshots[int](tt.depth)
+			s.finalize(tt.finalized)
+			pushAll(s, tt.pushes...)
+			if got := history(s); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotsFinalize(t *testing.T) {
+	s := pushAll(newSnapshots[int](1), 1, 2, 3)
+	s.finalize(1)
+	if got, want := history(s), []string{"2:1", "3:2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("finalizing an evicted block got %v, want %v", got, want)
+	}
+	s = pushAll(newSnapshots[int](2), 1, 2, 3, 4)
+	s.finalize(2)
+	s.finalize(1)
+	if s.finalized != 2 {
+		t.Fatalf("got finalized %d, want it to never go backwards", s.finalized)
+	}
+	s.push(5, 4)
+	s.push(6, 5)
+	if got, want := history(s), []string{"2:1", "3:2", "4:3", "5:4", "6:5"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestSnapshotsLookup(t *testing.T) {
+	s := pushAll(newSnapshots[int](10), 2, 4, 6)
+	tests := []struct {
+		block      int64
+		wantBefore string
+		wantAt     string
+	}{
+		{block: 1, wantBefore: "none", wantAt: "none"},
+		{block: 2, wantBefore: "2:0", wantAt: "2:0"},
+		{block: 3, wantBefore: "2:0", wantAt: "none"},
+		{block: 6, wantBefore: "6:2", wantAt: "6:2"},
+		{block: 9, wantBefore: "6:2", wantAt: "none"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.block), func(t *testing.T) {
+			before := "none"
+			if value, at, ok := s.before(tt.block); ok {
+				before = fmt.Sprintf("%d:%d", at, value)
+			}
+			if before != tt.wantBefore {
+				t.Fatalf("before got %v, want %v", before, tt.wantBefore)
+			}
+			exact := "none"
+			if value, ok := s.at(tt.block); ok {
+				exact = fmt.Sprintf("%d:%d", tt.block, value)
+			}
+			if exact != tt.wantAt {
+				t.Fatalf("at got %v, want %v", exact, tt.wantAt)
+			}
+		})
+	}
+	if got := s.latest(); got != 6 {
+		t.Fatalf("got latest %d, want 6", got)
+	}
+	if got := newSnapshots[int](1).latest(); got != -1 {
+		t.Fatalf("got latest %d for an empty history, want -1", got)
+	}
+}
+
+func TestSnapshotsRewind(t *testing.T) {
+	tests := []struct {
+		name   string
+		depth  int
+		pushes []int64
+		block  int64
+		wantAt int64
+		wantOK bool
+		want   []string
+	}{
+		{
+			name:   "between snapshots",
+			depth:  10,
+			pushes: []int64{2, 4, 6},
+			block:  5,
+			wantAt: 4,
+			wantOK: true,
+			want:   []string{"2:0", "4:1"},
+		},
+		{
+			name:   "to a snapshot",
+			depth:  10,
+			pushes: []int64{2, 4, 6},
+			block:  4,
+			wantAt: 4,
+			wantOK: true,
+			want:   []string{"2:0", "4:1"},
+		},
+		{
+			name:   "to the latest",
+			depth:  10,
+			pushes: []int64{2, 4, 6},
+			block:  6,
+			wantAt: 6,
+			wantOK: true,
+			want:   []string{"2:0", "4:1", "6:2"},
+		},
+		{
+			name:   "before the first snapshot",
+			depth:  10,
+			pushes: []int64{2, 4, 6},
+			block:  1,
+			wantAt: -1,
+			wantOK: true,
+			want:   []string{},
+		},
+		{
+			name:   "before the history after eviction",
+			depth:  1,
+			pushes: []int64{1, 2, 3},
+			block:  1,
+			wantAt: -1,
+			wantOK: false,
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := pushAll(newSnapshots[int](tt.depth), tt.pushes...)
+			_, at, ok := s.rewind(tt.block)
+			if at != tt.wantAt || ok != tt.wantOK {
+				t.Fatalf("got %d %v, want %d %v", at, ok, tt.wantAt, tt.wantOK)
+			}
+			if got := history(s); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotsReset(t *testing.T) {
+	s := pushAll(newSnapshots[int](10), 1, 2, 3)
+	s.finalize(2)
+	s.reset(5, 9)
+	if got, want := history(s), []string{"5:9"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if s.finalized != -1 {
+		t.Fatalf("got finalized %d, want -1", s.finalized)
+	}
+	if _, _, ok := s.rewind(4); ok {
+		t.Fatal("rewound past a reset")
+	}
+}
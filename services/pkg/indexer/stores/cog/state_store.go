@@ -7,9 +7,11 @@ import (
 	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/playmint/ds-node/pkg/api/model"
 	"github.com/playmint/ds-node/pkg/contracts/state"
+	"github.com/playmint/ds-node/pkg/indexer/checkpoint"
 	"github.com/playmint/ds-node/pkg/indexer/eventwatcher"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -21,25 +23,38 @@ type OpSet struct {
 	Ops     []interface{}
 }
 
+// contractState is everything the store holds for a single state contract,
+// each game deployment has its own state contract and so its own graph
+type contractState struct {
+	graph          *model.Graph
+	pendingGraph   *model.Graph
+	finalizedGraph *model.Graph
+	pendingOpSets  []OpSet
+	history        *snapshots[*model.Graph]
+}
+
 type StateStore struct {
-	graph         *model.Graph
-	pendingGraph  *model.Graph
+	states        map[common.Address]*contractState
+	lastBlock     int64
 	abi           *abi.ABI
 	log           zerolog.Logger
 	notifications chan interface{}
-	pendingOpSets []OpSet
+	config        Config
 	sync.RWMutex
 }
 
-func NewStateStore(ctx context.Context, watcher *eventwatcher.Watcher, notifications chan interface{}) (*StateStore, error) {
+func NewStateStore(ctx context.Context, watcher *eventwatcher.Watcher, notifications chan interface{}, cfg Config) (*StateStore, error) {
 	cabi, err := abi.JSON(strings.NewReader(state.StateABI))
 	if err != nil {
 		panic(err)
 	}
 	store := &StateStore{
+		states:        map[common.Address]*contractState{},
+		lastBlock:     -1,
 		abi:           &cabi,
 		log:           log.With().Str("service", "indexer").Str("component", "statestore").Str("name", "latest").Logger(),
 		notifications: notifications,
+		config:        cfg,
 	}
 	store.watch(ctx, watcher)
 	return store, nil
@@ -60,32 +75,88 @@ func (rs *StateStore) watch(ctx context.Context, watcher *eventwatcher.Watcher)
 	if err != nil {
 		panic(err)
 	}
-	events := watcher.SubscribeTopic(topics[0])
-	go rs.watchLoop(ctx, events)
+	events := watcher.SubscribeTopic("state", topics[0])
+	go rs.watchLoop(ctx, events.C())
+}
+
+// contract returns the state for the given state contract address, creating
+// it if this is the first time it has been seen. must be called with the lock
+// held.
+func (rs *StateStore) contract(addr common.Address) *contractState {
+	cs, ok := rs.states[addr]
+	if !ok {
+		cs = rs.newContractState()
+		rs.states[addr] = cs
+	}
+	return cs
+}
+
+// newContractState returns an empty contractState whose history is deep
+// enough both to rewind any expected reorg and to answer historical queries
+func (rs *StateStore) newContractState() *contractState {
+	depth := rs.config.StateHistoryBlocks
+	if rs.config.ReorgDepth > depth {
+		depth = rs.config.ReorgDepth
+	}
+	return &contractState{
+		history: newSnapshots[*model.Graph](depth),
+	}
+}
+
+type stateNotification struct {
+	addr    common.Address
+	sigs    []string
+	changed bool
 }
 
 func (rs *StateStore) processBlock(ctx context.Context, block *eventwatcher.LogBatch) {
 	rs.Lock()
-	g := rs.graph
 
-	if g == nil {
-		g = model.NewGraph(0)
+	// if the chain was reset start again from nothing, if it reorged roll
+	// back to the state as it was at the common ancestor, either way the
+	// batch contains all the canonical logs since then
+	rewindBlock, rollback := block.Rewind()
+	rolledBack := map[common.Address]bool{}
+	if block.Reset {
+		rs.states = map[common.Address]*contractState{}
+		rs.lastBlock = -1
+		rs.log.Warn().Msg("reset")
+	} else if rollback {
+		for addr, cs := range rs.states {
+			if cs.history.latest() <= rewindBlock {
+				continue // nothing changed since the common ancestor
+			}
+			cs.graph = rs.rewind(addr, cs, rewindBlock)
+			rolledBack[addr] = true
+		}
 	}
 
-	execOps := 0
-	seenOps := map[string]bool{}
+	execOps := map[common.Address]int{}
+	seenOps := map[common.Address]map[string]bool{}
+	dirty := map[common.Address]bool{}
+	eventBlock := int64(-1)
 	for _, rawEvent := range block.Logs {
 		if rawEvent.Removed {
-			// FIXME: ignoring reorg
-			rs.log.Warn().Msgf("unhandled reorg %v", rawEvent)
+			// removed logs are superseded by the reorg batch
 			continue
 		}
+		// a batch can span many blocks, keep a snapshot at the end of each
+		// block that changed something so that historical queries are exact
+		if b := int64(rawEvent.BlockNumber); b != eventBlock {
+			rs.pushDirty(dirty, eventBlock)
+			eventBlock = b
+		}
 		eventABI, err := rs.abi.EventByID(rawEvent.Topics[0])
 		if err != nil {
 			rs.log.Debug().Msgf("unhandleable event topic: %v", err)
 			continue
 		}
 		rs.log.Debug().Msgf("recv %v", eventABI.RawName)
+		cs := rs.contract(rawEvent.Address)
+		g := cs.graph
+		if g == nil {
+			g = model.NewGraph(0)
+		}
 		switch eventABI.RawName {
 		case "AnnotationSet":
 			var evt state.StateAnnotationSet
@@ -98,7 +169,6 @@ func (rs *StateStore) processBlock(ctx context.Context, block *eventwatcher.LogB
 			if err != nil {
 				rs.log.Error().Err(err).Msgf("failed process %T event", evt)
 			}
-			execOps++
 		case "DataSet":
 			var evt state.StateDataSet
 			if err := unpackLog(rs.abi, &evt, eventABI.RawName, rawEvent); err != nil {
@@ -110,7 +180,6 @@ func (rs *StateStore) processBlock(ctx context.Context, block *eventwatcher.LogB
 			if err != nil {
 				rs.log.Error().Err(err).Msgf("failed process %T event", evt)
 			}
-			execOps++
 		case "EdgeSet":
 			var evt state.StateEdgeSet
 			if err := unpackLog(rs.abi, &evt, eventABI.RawName, rawEvent); err != nil {
@@ -122,7 +191,6 @@ func (rs *StateStore) processBlock(ctx context.Context, block *eventwatcher.LogB
 			if err != nil {
 				rs.log.Error().Err(err).Msgf("failed process %T event", evt)
 			}
-			execOps++
 		case "EdgeRemove":
 			var evt state.StateEdgeRemove
 			if err := unpackLog(rs.abi, &evt, eventABI.RawName, rawEvent); err != nil {
@@ -134,7 +202,6 @@ func (rs *StateStore) processBlock(ctx context.Context, block *eventwatcher.LogB
 			if err != nil {
 				rs.log.Error().Err(err).Msgf("failed process %T event", evt)
 			}
-			execOps++
 		case "NodeTypeRegister":
 			var evt state.StateNodeTypeRegister
 			if err := unpackLog(rs.abi, &evt, eventABI.RawName, rawEvent); err != nil {
@@ -146,15 +213,16 @@ func (rs *StateStore) processBlock(ctx context.Context, block *eventwatcher.LogB
 			if err != nil {
 				rs.log.Error().Err(err).Msgf("failed process %T event", evt)
 			}
-			execOps++
 		case "SeenOpSet":
 			var evt state.StateSeenOpSet
 			if err := unpackLog(rs.abi, &evt, eventABI.RawName, rawEvent); err != nil {
 				rs.log.Warn().Err(err).Msgf("undecodable %T event", evt)
 				continue
 			}
-			seenOps[hexutil.Encode(evt.Sig)] = true
-			execOps++
+			if seenOps[rawEvent.Address] == nil {
+				seenOps[rawEvent.Address] = map[string]bool{}
+			}
+			seenOps[rawEvent.Address][hexutil.Encode(evt.Sig)] = true
 		case "EdgeTypeRegister":
 			var evt state.StateEdgeTypeRegister
 			if err := unpackLog(rs.abi, &evt, eventABI.RawName, rawEvent); err != nil {
@@ -166,44 +234,106 @@ func (rs *StateStore) processBlock(ctx context.Context, block *eventwatcher.LogB
 			if err != nil {
 				rs.log.Error().Err(err).Msgf("failed process %T event", evt)
 			}
-			execOps++
 		default:
 			rs.log.Warn().Msgf("ignoring unhandled event type %v", eventABI)
+			continue
 		}
+		cs.graph = g
+		dirty[rawEvent.Address] = true
+		execOps[rawEvent.Address]++
 	}
-
-	// update
-	rs.pendingOpSets = rs.removePendingOpSets(rs.pendingOpSets, seenOps, block.ToBlock)
-	rs.graph = g
-	rs.pendingGraph = rs.rebuildPendingGraph()
+	rs.pushDirty(dirty, eventBlock)
+
+	// only contracts with logs in this batch or that were rolled back have
+	// a new state to snapshot and notify about, but pending opsets can
+	// expire and blocks can finalize for any of them
+	notifications := []stateNotification{}
+	for addr, cs := range rs.states {
+		changed := execOps[addr] > 0 || rolledBack[addr]
+		pending := len(cs.pendingOpSets)
+		cs.pendingOpSets = rs.removePendingOpSets(cs.pendingOpSets, seenOps[addr], block.ToBlock)
+		expired := len(cs.pendingOpSets) != pending
+		if changed || expired {
+			cs.pendingGraph = rs.rebuildPendingGraph(cs)
+		}
+		cs.history.finalize(block.Finalized)
+		if finalized, _, ok := cs.history.before(block.Finalized); ok {
+			cs.finalizedGraph = finalized
+		}
+		if !changed && !expired {
+			continue
+		}
+		sigs := []string{}
+		for sig := range seenOps[addr] {
+			sigs = append(sigs, sig)
+		}
+		notifications = append(notifications, stateNotification{
+			addr:    addr,
+			sigs:    sigs,
+			changed: changed,
+		})
+	}
+	rs.lastBlock = block.ToBlock
 	rs.Unlock()
 
 	// notify
 
-	sigs := []string{}
-	for sig := range seenOps {
-		sigs = append(sigs, sig)
+	for _, n := range notifications {
+		if n.changed {
+			rs.Notify(n.addr, int(block.ToBlock), n.sigs, false, rollback)
+		}
+
+		// send a notification that the pendingops have been rebased on the latest
+		// block state, but only if something has actually changed, not every block
+		rs.Notify(n.addr, int(block.ToBlock), []string{"PENDING"}, true, rollback)
 	}
-	rs.Notify(int(block.ToBlock), sigs, false)
 
-	// send a notification that the pendingops have been rebased on the latest
-	// block state, but only if something has actually changed, not every block
-	if execOps > 0 {
-		rs.Notify(int(block.ToBlock), []string{"PENDING"}, true)
+}
+
+// pushDirty records the current graph of every contract in dirty as the state
+// at the end of block and clears dirty
+func (rs *StateStore) pushDirty(dirty map[common.Address]bool, block int64) {
+	for addr := range dirty {
+		cs := rs.states[addr]
+		cs.history.push(block, cs.graph)
+		delete(dirty, addr)
 	}
+}
 
+// rewind returns the contract's graph as it was at the end of the given
+// block, if the history does not go back that far the current graph is kept
+// and the state will be wrong until the affected nodes are next updated
+func (rs *StateStore) rewind(addr common.Address, cs *contractState, block int64) *model.Graph {
+	snapshot, at, ok := cs.history.rewind(block)
+	if !ok {
+		rs.log.Error().
+			Str("state", addr.Hex()).
+			Int64("block", block).
+			Msg("reorg-deeper-than-history")
+		return cs.graph
+	}
+	rs.log.Warn().
+		Str("state", addr.Hex()).
+		Int64("block", block).
+		Int64("snapshot", at).
+		Msg("rewind")
+	return snapshot
 }
 
-func (rs *StateStore) Notify(blockNumber int, sigs []string, simulated bool) {
-	rs.notifications <- &model.BlockEvent{
-		ID:        fmt.Sprintf("block-%d", blockNumber),
-		Block:     blockNumber,
-		Sigs:      sigs,
-		Simulated: simulated,
+func (rs *StateStore) Notify(stateContractAddr common.Address, blockNumber int, sigs []string, simulated bool, rollback bool) {
+	rs.notifications <- &model.StateEvent{
+		StateID: stateContractAddr.Hex(),
+		Event: &model.BlockEvent{
+			ID:        fmt.Sprintf("block-%d", blockNumber),
+			Block:     blockNumber,
+			Sigs:      sigs,
+			Simulated: simulated,
+			Rollback:  rollback,
+		},
 	}
 }
 
-func (rs *StateStore) watchLoop(ctx context.Context, blocks chan *eventwatcher.LogBatch) {
+func (rs *StateStore) watchLoop(ctx context.Context, blocks <-chan *eventwatcher.LogBatch) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -291,13 +421,110 @@ func (rs *StateStore) removeEdge(g *model.Graph, evt *state.StateEdgeRemove) (*m
 	return g, nil
 }
 
-func (rs *StateStore) GetGraph() *model.Graph {
+// LastBlock returns the last block processed by the store or -1 if none
+func (rs *StateStore) LastBlock() int64 {
+	rs.RLock()
+	defer rs.RUnlock()
+	return rs.lastBlock
+}
+
+// Checkpoint fills cp with every state contract's graph as it was at the end
+// of block, it returns false if the store no longer holds the state for that
+// block
+func (rs *StateStore) Checkpoint(block int64, cp *checkpoint.Checkpoint) bool {
+	// graphs are immutable so only hold the lock while picking them out,
+	// serializing them can take a while for large states
+	rs.RLock()
+	picked := map[string]*model.Graph{}
+	for addr, cs := range rs.states {
+		g, _, ok := cs.history.before(block)
+		if !ok {
+			if cs.history.evicted {
+				rs.RUnlock()
+				return false
+			}
+			continue // first seen after block
+		}
+		if g != nil {
+			picked[addr.Hex()] = g
+		}
+	}
+	rs.RUnlock()
+	graphs := map[string]*model.GraphSnapshot{}
+	for addr, g := range picked {
+		graphs[addr] = g.Snapshot()
+	}
+	cp.Graphs = graphs
+	return true
+}
+
+// Restore replaces the graphs with those from a checkpoint, it must be
+// called before the watcher is started
+func (rs *StateStore) Restore(cp *checkpoint.Checkpoint) error {
+	states := map[common.Address]*contractState{}
+	for addr, snapshot := range cp.Graphs {
+		g, err := model.NewGraphFromSnapshot(snapshot)
+		if err != nil {
+			return err
+		}
+		cs := rs.newContractState()
+		cs.graph = g
+		cs.pendingGraph = g
+		cs.history.reset(cp.Block, g)
+		states[common.HexToAddress(addr)] = cs
+	}
 	rs.Lock()
 	defer rs.Unlock()
-	return rs.graph
+	rs.states = states
+	rs.lastBlock = cp.Block
+	return nil
+}
+
+// GetGraph returns the latest graph for the given state contract or nil if
+// no state has been seen for it
+func (rs *StateStore) GetGraph(stateContractAddr common.Address) *model.Graph {
+	rs.RLock()
+	defer rs.RUnlock()
+	cs, ok := rs.states[stateContractAddr]
+	if !ok {
+		return nil
+	}
+	return cs.graph
+}
+
+// GetGraphAt returns the graph for the given state contract as it was at the
+// end of block. It returns an error if block is outside of the retained
+// history.
+func (rs *StateStore) GetGraphAt(stateContractAddr common.Address, block int64) (*model.Graph, error) {
+	rs.RLock()
+	defer rs.RUnlock()
+	if block > rs.lastBlock {
+		return nil, fmt.Errorf("block %d has not been indexed yet", block)
+	}
+	cs, ok := rs.states[stateContractAddr]
+	if !ok {
+		return nil, nil
+	}
+	g, _, ok := cs.history.before(block)
+	if !ok && cs.history.evicted {
+		return nil, fmt.Errorf("block %d is older than the retained state history", block)
+	}
+	return g, nil
 }
 
-func (rs *StateStore) AddPendingOpSet(estimatedBlockNumber int, opset OpSet) {
+// GetFinalizedGraph returns the most recent graph for the given state
+// contract that can no longer be changed by a chain reorg
+func (rs *StateStore) GetFinalizedGraph(stateContractAddr common.Address) *model.Graph {
+	rs.RLock()
+	defer rs.RUnlock()
+	cs, ok := rs.states[stateContractAddr]
+	if !ok {
+		return nil
+	}
+	return cs.finalizedGraph
+}
+
+func (rs *StateStore) AddPendingOpSet(stateContractAddr common.Address, estimatedBlockNumber int, opset OpSet) {
 	// default expiry to ~30 blocks in future this means we will stop waiting
 	// for the pending sig to arrive if we don't hear anything within about 1m
 	if opset.Expires == 0 {
@@ -305,17 +532,22 @@ func (rs *StateStore) AddPendingOpSet(estimatedBlockNumber int, opset OpSet) {
 	}
 
 	rs.Lock()
-	rs.pendingOpSets = append(rs.pendingOpSets, opset)
-	rs.pendingGraph = rs.rebuildPendingGraph()
+	cs := rs.contract(stateContractAddr)
+	cs.pendingOpSets = append(cs.pendingOpSets, opset)
+	cs.pendingGraph = rs.rebuildPendingGraph(cs)
 	rs.Unlock()
 
-	rs.Notify(estimatedBlockNumber, []string{opset.Sig}, true)
+	rs.Notify(stateContractAddr, estimatedBlockNumber, []string{opset.Sig}, true, false)
 }
 
-func (rs *StateStore) RemovePendingOpSets(seenOps map[string]bool) {
+func (rs *StateStore) RemovePendingOpSets(stateContractAddr common.Address, seenOps map[string]bool) {
 	rs.Lock()
 	defer rs.Unlock()
-	rs.pendingOpSets = rs.removePendingOpSets(rs.pendingOpSets, seenOps, -1)
+	cs, ok := rs.states[stateContractAddr]
+	if !ok {
+		return
+	}
+	cs.pendingOpSets = rs.removePendingOpSets(cs.pendingOpSets, seenOps, -1)
 }
 
 func (rs *StateStore) removePendingOpSets(existingOpSets []OpSet, seenOps map[string]bool, currentBlock int64) []OpSet {
@@ -332,16 +564,24 @@ func (rs *StateStore) removePendingOpSets(existingOpSets []OpSet, seenOps map[st
 	return newPendingOpSets
 }
 
-func (rs *StateStore) GetPendingGraph() *model.Graph {
-	return rs.pendingGraph
+// GetPendingGraph returns the latest graph for the given state contract with
+// any pending opsets applied on top
+func (rs *StateStore) GetPendingGraph(stateContractAddr common.Address) *model.Graph {
+	rs.RLock()
+	defer rs.RUnlock()
+	cs, ok := rs.states[stateContractAddr]
+	if !ok {
+		return nil
+	}
+	return cs.pendingGraph
 }
 
-func (rs *StateStore) rebuildPendingGraph() *model.Graph {
-	g := rs.graph
+func (rs *StateStore) rebuildPendingGraph(cs *contractState) *model.Graph {
+	g := cs.graph
 	if g == nil {
 		return nil
 	}
-	for _, opset := range rs.pendingOpSets {
+	for _, opset := range cs.pendingOpSets {
 		for _, op := range opset.Ops {
 			var err error
 			var g2 *model.Graph
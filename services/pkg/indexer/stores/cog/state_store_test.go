@@ -0,0 +1,311 @@
+package cog
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/playmint/ds-node/pkg/api/model"
+	"github.com/playmint/ds-node/pkg/contracts/state"
+	"github.com/playmint/ds-node/pkg/indexer/eventwatcher"
+	"github.com/rs/zerolog"
+)
+
+var testStateAddr = common.HexToAddress("0x5fbdb2315678afecb367f032d93f642f64180aa3")
+
+func newTestStateStore(t *testing.T) *StateStore {
+	cabi, err := abi.JSON(strings.NewReader(state.StateABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &StateStore{
+		states:        map[common.Address]*contractState{},
+		lastBlock:     -1,
+		abi:           &cabi,
+		log:           zerolog.Nop(),
+		notifications: make(chan interface{}, 1024),
+		config:        Config{ReorgDepth: 64, StateHistoryBlocks: 256},
+	}
+}
+
+// makeLog encodes an event emitted by the state contract in the given block
+func makeLog(t *testing.T, cabi *abi.ABI, name string, block uint64, args ...interface{}) types.Log {
+	event := cabi.Events[name]
+	topics := [][]interface{}{{event.ID}}
+	data := []interface{}{}
+	for i, input := range event.Inputs {
+		if input.Indexed {
+			topics = append(topics, []interface{}{args[i]})
+		} else {
+			data = append(data, args[i])
+		}
+	}
+	hashes, err := abi.MakeTopics(topics...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packed, err := event.Inputs.NonIndexed().Pack(data...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := types.Log{
+		Address:     testStateAddr,
+		BlockNumber: block,
+		Data:        packed,
+	}
+	for _, h := range hashes {
+		log.Topics = append(log.Topics, h[0])
+	}
+	return log
+}
+
+func dataSetLog(t *testing.T, cabi *abi.ABI, block uint64, id [24]byte, label string, value int64) types.Log {
+	var data [32]byte
+	big.NewInt(value).FillBytes(data[:])
+	return makeLog(t, cabi, "DataSet", block, id, label, data)
+}
+
+// hp returns the node's hp data or -1 if it has none
+func hp(t *testing.T, g *model.Graph, nodeID string) int64 {
+	if g == nil {
+		return -1
+	}
+	node := g.GetNode(&model.Match{Ids: []string{nodeID}})
+	if node == nil || node.Data("hp") == nil {
+		return -1
+	}
+	raw, err := hexutil.Decode(node.Data("hp").Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return new(big.Int).SetBytes(raw).Int64()
+}
+
+func TestStateStoreGetGraphAtWithinBatch(t *testing.T) {
+	rs := newTestStateStore(t)
+	var id [24]byte
+	id[0] = 0x01
+	nodeID := hexutil.Encode(id[:])
+
+	// a single batch covering blocks 10-15 with changes in blocks 10 and 12
+	rs.processBlock(context.Background(), &eventwatcher.LogBatch{
+		EventBatch: eventwatcher.EventBatch{FromBlock: 10, ToBlock: 15},
+		Logs: []types.Log{
+			dataSetLog(t, rs.abi, 10, id, "hp", 1),
+			dataSetLog(t, rs.abi, 12, id, "hp", 2),
+		},
+		Finalized: -1,
+	})
+
+	tests := []struct {
+		block int64
+		want  int64
+	}{
+		{block: 10, want: 1},
+		{block: 11, want: 1},
+		{block: 12, want: 2},
+		{block: 14, want: 2},
+		{block: 15, want: 2},
+	}
+	for _, tt := range tests {
+		g, err := rs.GetGraphAt(testStateAddr, tt.block)
+		if err != nil {
+			t.Fatalf("block %d: %v", tt.block, err)
+		}
+		if got := hp(t, g, nodeID); got != tt.want {
+			t.Errorf("block %d: hp = %v, want %v", tt.block, got, tt.want)
+		}
+	}
+
+	if _, err := rs.GetGraphAt(testStateAddr, 16); err == nil {
+		t.Errorf("block 16: expected an error for a block not yet indexed")
+	}
+}
+
+func TestStateStoreRewind(t *testing.T) {
+	var id [24]byte
+	id[0] = 0x01
+	nodeID := hexutil.Encode(id[:])
+	cabi := newTestStateStore(t).abi
+
+	tests := []struct {
+		name         string
+		batch        eventwatcher.LogBatch
+		want         map[int64]int64
+		wantRollback bool
+	}{
+		{
+			name: "extends",
+			batch: eventwatcher.LogBatch{
+				EventBatch: eventwatcher.EventBatch{FromBlock: 13, ToBlock: 14},
+				Logs:       []types.Log{dataSetLog(t, cabi, 14, id, "hp", 3)},
+			},
+			want: map[int64]int64{10: 1, 11: 1, 12: 2, 13: 2, 14: 3},
+		},
+		{
+			name: "reorg",
+			batch: eventwatcher.LogBatch{
+				EventBatch: eventwatcher.EventBatch{FromBlock: 12, ToBlock: 13},
+				Logs:       []types.Log{dataSetLog(t, cabi, 13, id, "hp", 3)},
+				Reorg:      true,
+			},
+			want:         map[int64]int64{11: 1, 12: 1, 13: 3},
+			wantRollback: true,
+		},
+		{
+			name: "reorg without changes",
+			batch: eventwatcher.LogBatch{
+				EventBatch: eventwatcher.EventBatch{FromBlock: 12, ToBlock: 13},
+				Reorg:      true,
+			},
+			want:         map[int64]int64{11: 1, 13: 1},
+			wantRollback: true,
+		},
+		{
+			name: "reorg past the first snapshot",
+			batch: eventwatcher.LogBatch{
+				EventBatch: eventwatcher.EventBatch{FromBlock: 5, ToBlock: 13},
+				Reorg:      true,
+			},
+			want:         map[int64]int64{11: -1, 13: -1},
+			wantRollback: true,
+		},
+		{
+			name: "reset",
+			batch: eventwatcher.LogBatch{
+				EventBatch: eventwatcher.EventBatch{FromBlock: 0, ToBlock: 5},
+				Logs:       []types.Log{dataSetLog(t, cabi, 4, id, "hp", 7)},
+				Reset:      true,
+			},
+			want: map[int64]int64{5: 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := newTestStateStore(t)
+			for _, batch := range []eventwatcher.LogBatch{
+				{
+					EventBatch: eventwatcher.EventBatch{FromBlock: 10, ToBlock: 11},
+					Logs:       []types.Log{dataSetLog(t, rs.abi, 10, id, "hp", 1)},
+					Finalized:  -1,
+				},
+				{
+					EventBatch: eventwatcher.EventBatch{FromBlock: 12, ToBlock: 12},
+					Logs:       []types.Log{dataSetLog(t, rs.abi, 12, id, "hp", 2)},
+					Finalized:  -1,
+				},
+			} {
+				batch := batch
+				rs.processBlock(context.Background(), &batch)
+			}
+			for len(rs.notifications) > 0 {
+				<-rs.notifications
+			}
+			batch := tt.batch
+			batch.Finalized = -1
+			rs.processBlock(context.Background(), &batch)
+
+			for block, want := range tt.want {
+				g, err := rs.GetGraphAt(testStateAddr, block)
+				if err != nil {
+					t.Fatalf("block %d: %v", block, err)
+				}
+				if got := hp(t, g, nodeID); got != want {
+					t.Errorf("block %d: hp = %v, want %v", block, got, want)
+				}
+			}
+			if _, err := rs.GetGraphAt(testStateAddr, batch.ToBlock+1); err == nil {
+				t.Errorf("block %d: expected an error for a block not yet indexed", batch.ToBlock+1)
+			}
+			if got := hp(t, rs.GetGraph(testStateAddr), nodeID); got != tt.want[batch.ToBlock] {
+				t.Errorf("latest hp = %v, want %v", got, tt.want[batch.ToBlock])
+			}
+			evt := (<-rs.notifications).(*model.StateEvent).Event.(*model.BlockEvent)
+			if evt.Rollback != tt.wantRollback {
+				t.Errorf("got rollback %v, want %v", evt.Rollback, tt.wantRollback)
+			}
+		})
+	}
+}
+
+func TestStateStoreOnlyUpdatesChangedContracts(t *testing.T) {
+	rs := newTestStateStore(t)
+	var id [24]byte
+	id[0] = 0x01
+	nodeID := hexutil.Encode(id[:])
+	other := common.HexToAddress("0xe7f1725e7734ce288f8367e1bb143e90bb3f0512")
+	otherLog := func(block uint64, value int64) types.Log {
+		log := dataSetLog(t, rs.abi, block, id, "hp", value)
+		log.Address = other
+		return log
+	}
+	drain := func() []*model.StateEvent {
+		events := []*model.StateEvent{}
+		for len(rs.notifications) > 0 {
+			events = append(events, (<-rs.notifications).(*model.StateEvent))
+		}
+		return events
+	}
+
+	rs.processBlock(context.Background(), &eventwatcher.LogBatch{
+		EventBatch: eventwatcher.EventBatch{FromBlock: 10, ToBlock: 10},
+		Logs:       []types.Log{dataSetLog(t, rs.abi, 10, id, "hp", 1), otherLog(10, 5)},
+		Finalized:  -1,
+	})
+	drain()
+
+	tests := []struct {
+		name         string
+		batch        eventwatcher.LogBatch
+		wantRollback bool
+	}{
+		{
+			name: "logs for one contract",
+			batch: eventwatcher.LogBatch{
+				EventBatch: eventwatcher.EventBatch{FromBlock: 11, ToBlock: 12},
+				Logs:       []types.Log{dataSetLog(t, rs.abi, 12, id, "hp", 2)},
+			},
+		},
+		{
+			name: "reorg of one contract",
+			batch: eventwatcher.LogBatch{
+				EventBatch: eventwatcher.EventBatch{FromBlock: 12, ToBlock: 12},
+				Reorg:      true,
+			},
+			wantRollback: true,
+		},
+	}
+	for _, tt := range tests {
+		batch := tt.batch
+		batch.Finalized = -1
+		rs.processBlock(context.Background(), &batch)
+
+		events := drain()
+		if len(events) == 0 {
+			t.Fatalf("%s: no notifications", tt.name)
+		}
+		for _, evt := range events {
+			if evt.StateID != testStateAddr.Hex() {
+				t.Errorf("%s: notified unchanged state %s", tt.name, evt.StateID)
+			}
+			if got := evt.Event.(*model.BlockEvent).Rollback; got != tt.wantRollback {
+				t.Errorf("%s: got rollback %v, want %v", tt.name, got, tt.wantRollback)
+			}
+		}
+		if got := rs.states[other].history.blocks; len(got) != 1 || got[0] != 10 {
+			t.Errorf("%s: unchanged state has snapshots at %v, want [10]", tt.name, got)
+		}
+		g, err := rs.GetGraphAt(other, 12)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := hp(t, g, nodeID); got != 5 {
+			t.Errorf("%s: unchanged state hp = %v, want 5", tt.name, got)
+		}
+	}
+}
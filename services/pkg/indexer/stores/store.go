@@ -0,0 +1,142 @@
+package stores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/playmint/ds-node/pkg/client/alchemy"
+	"github.com/playmint/ds-node/pkg/indexer/eventwatcher"
+	"github.com/rs/zerolog/log"
+)
+
+// Store is an extra indexer that runs alongside the built in cog stores. It
+// receives batches from the same event watcher, in the same block order, and
+// the indexer is not ready until every store has caught up.
+type Store interface {
+	// Topics are the event topics the store wants, batches include the logs
+	// for every store's topics so stores must ignore logs they do not handle
+	Topics() []common.Hash
+	// ProcessBatch is called for every batch published by the watcher, if
+	// batch.Reset is set the store must discard everything it holds and if
+	// batch.Rewind() is set it must roll back before applying it
+	ProcessBatch(ctx context.Context, batch *eventwatcher.LogBatch) error
+	// Query returns json serialisable data for the "store" graphql query
+	Query(ctx context.Context, key *string) (interface{}, error)
+}
+
+// Checkpointer is implemented by stores that can be saved in and restored
+// from indexer checkpoints. Checkpoints are disabled if any registered store
+// does not implement it.
+type Checkpointer interface {
+	// LastBlock returns the last block processed by the store or -1 if none
+	LastBlock() int64
+	// Checkpoint returns the contents of the store as of the end of block,
+	// ok is false if the store no longer holds the state for that block.
+	// block is final and at least INDEXER_REORG_DEPTH blocks behind
+	// LastBlock, so stores must keep at least that much history.
+	Checkpoint(block int64) (data json.RawMessage, ok bool)
+	// Restore replaces the contents of the store with data from a checkpoint
+	// taken at block, it is called before the watcher is started
+	Restore(block int64, data json.RawMessage) error
+}
+
+// Config is passed to each Factory
+type Config struct {
+	// HTTPClient is nil when replaying without a provider
+	HTTPClient    *alchemy.Client
+	Watcher       *eventwatcher.Watcher
+	Notifications chan interface{}
+}
+
+// Factory builds a store, it is called once by NewMemoryIndexer
+type Factory func(ctx context.Context, cfg Config) (Store, error)
+
+var (
+	factories     = map[string]Factory{}
+	factoriesLock sync.Mutex
+)
+
+// Register makes a store available to the indexer under name, it is
+// intended to be called from the init function of the package providing
+// the store and panics if the name is already taken
+func Register(name string, factory Factory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+	if _, exists := factories[name]; exists {
+		panic(fmt.Sprintf("stores: Register called twice for %v", name))
+	}
+	factories[name] = factory
+}
+
+// Registered returns the names of every registered store
+func Registered() []string {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+	names := []string{}
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New builds every registered store, keyed by name
+func New(ctx context.Context, cfg Config) (map[string]Store, error) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+	stores := map[string]Store{}
+	for name, factory := range factories {
+		store, err := factory(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("stores: failed to create %v: %v", name, err)
+		}
+		stores[name] = store
+	}
+	return stores, nil
+}
+
+// Run subscribes the store to its topics on the watcher and feeds it
+// batches until ctx is done, it must be called before the watcher is started.
+// The returned channel is closed once the watcher is ready and the store has
+// processed every batch the watcher published while catching up.
+func Run(ctx context.Context, name string, store Store, watcher *eventwatcher.Watcher) <-chan struct{} {
+	logger := log.With().Str("service", "indexer").Str("component", "store").Str("name", name).Logger()
+	sub := watcher.SubscribeTopic(name, store.Topics())
+	ready := make(chan struct{})
+	go func() {
+		defer watcher.Unsubscribe(sub)
+		watcherReady := watcher.Ready()
+		lastBlock := int64(-1)
+		caughtUp := false
+		checkCaughtUp := func() {
+			if !caughtUp && watcherReady == nil && lastBlock >= watcher.ReadyBlock() {
+				caughtUp = true
+				close(ready)
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-watcherReady:
+				watcherReady = nil
+				checkCaughtUp()
+			case batch := <-sub.C():
+				if err := store.ProcessBatch(ctx, batch); err != nil {
+					logger.Error().
+						Err(err).
+						Int64("from", batch.FromBlock).
+						Int64("to", batch.ToBlock).
+						Msg("process-batch-fail")
+				}
+				lastBlock = batch.ToBlock
+				checkCaughtUp()
+			}
+		}
+	}()
+	return ready
+}
@@ -0,0 +1,74 @@
+package stores
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/playmint/ds-node/pkg/indexer/eventwatcher"
+)
+
+// gatedStore only finishes processing a batch once it is released
+type gatedStore struct {
+	release   chan struct{}
+	processed chan int64
+}
+
+func (s *gatedStore) Topics() []common.Hash {
+	return []common.Hash{}
+}
+
+func (s *gatedStore) ProcessBatch(ctx context.Context, batch *eventwatcher.LogBatch) error {
+	<-s.release
+	s.processed <- batch.ToBlock
+	return nil
+}
+
+func (s *gatedStore) Query(ctx context.Context, key *string) (interface{}, error) {
+	return nil, nil
+}
+
+func TestRunReadyWaitsForStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher, err := eventwatcher.New(eventwatcher.Config{
+		LogRange: 1,
+		Replay: strings.NewReader(
+			`{"fromBlock":0,"toBlock":4,"finalized":0,"logs":[]}` + "\n" +
+				`{"fromBlock":5,"toBlock":9,"finalized":0,"logs":[]}` + "\n",
+		),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &gatedStore{
+		release:   make(chan struct{}),
+		processed: make(chan int64, 2),
+	}
+	ready := Run(ctx, "gated", store, watcher)
+	watcher.Start(ctx)
+
+	select {
+	case <-watcher.Ready():
+	case <-time.After(5 * time.Second):
+		t.Fatal("watcher never became ready")
+	}
+	for _, want := range []int64{4, 9} {
+		select {
+		case <-ready:
+			t.Fatalf("ready before block %d was processed", want)
+		default:
+		}
+		store.release <- struct{}{}
+		if got := <-store.processed; got != want {
+			t.Fatalf("processed block %d, want %d", got, want)
+		}
+	}
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("store never became ready")
+	}
+}
@@ -1,16 +1,125 @@
 package mgmt
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi"
+	"github.com/playmint/ds-node/pkg/devchain"
+	"github.com/playmint/ds-node/pkg/indexer/checkpoint"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
 )
 
+// Snapshotter is implemented by indexers that can export their contents
+type Snapshotter interface {
+	Snapshot() (*checkpoint.Checkpoint, error)
+}
+
+// Server is the management server, it exposes metrics and, if their
+// dependencies are given, the snapshot export and dev chain controls
+type Server struct {
+	// Snapshotter, if set, enables the /snapshot endpoint which exports
+	// its contents in the format read by INDEXER_SNAPSHOT_PATH
+	Snapshotter Snapshotter
+	// DevChain, if set, enables the /dev endpoints for controlling a local
+	// chain, it should only be set in dev mode
+	DevChain *devchain.Controller
+}
+
 // ListenAndServe starts the management server to expose metrics, config and
 // health status
-func ListenAndServe(addr string) error {
+func (s *Server) ListenAndServe(addr string) error {
+	return http.ListenAndServe(addr, s.Handler())
+}
+
+// Handler returns the routes served by the management server, those without
+// their dependency respond 404
+func (s *Server) Handler() http.Handler {
 	router := chi.NewRouter()
 	router.Handle("/metrics", promhttp.Handler())
-	return http.ListenAndServe(addr, router)
+	router.Get("/snapshot", s.snapshotHandler)
+	router.Route("/dev", func(r chi.Router) {
+		r.Post("/mine", s.devHandler(mineHandler))
+		r.Post("/automine", s.devHandler(autoMineHandler))
+		r.Post("/reset", s.devHandler(resetHandler))
+	})
+	return router
+}
+
+func (s *Server) snapshotHandler(w http.ResponseWriter, r *http.Request) {
+	if s.Snapshotter == nil {
+		http.Error(w, "snapshot export not enabled", http.StatusNotFound)
+		return
+	}
+	cp, err := s.Snapshotter.Snapshot()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"snapshot-%d-%d.json\"", cp.ChainID, cp.Block))
+	if err := checkpoint.WriteSnapshot(w, cp); err != nil {
+		log.Error().Err(err).Str("service", "mgmt").Msg("snapshot-write-fail")
+	}
+}
+
+// devHandler responds 404 unless dev mode is enabled, then calls h and
+// responds with any error it returns
+func (s *Server) devHandler(h func(c *devchain.Controller, r *http.Request) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.DevChain == nil {
+			http.Error(w, "dev mode not enabled", http.StatusNotFound)
+			return
+		}
+		if err := h(s.DevChain, r); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// POST /dev/mine?blocks=N mines N blocks, default 1
+func mineHandler(c *devchain.Controller, r *http.Request) error {
+	blocks, err := queryUint(r, "blocks", 1)
+	if err != nil {
+		return err
+	}
+	return c.Mine(r.Context(), blocks)
+}
+
+// POST /dev/automine?enabled=true|false
+func autoMineHandler(c *devchain.Controller, r *http.Request) error {
+	enabled, err := strconv.ParseBool(r.URL.Query().Get("enabled"))
+	if err != nil {
+		return fmt.Errorf("invalid enabled: %v", err)
+	}
+	return c.SetAutoMine(r.Context(), enabled)
+}
+
+// POST /dev/reset?block=N forks DEV_FORK_URL at block N, or its latest block
+// if there is no block, or resets to a fresh chain if there is no fork url
+func resetHandler(c *devchain.Controller, r *http.Request) error {
+	if r.URL.Query().Get("block") == "" {
+		return c.Reset(r.Context(), nil)
+	}
+	block, err := queryUint(r, "block", 0)
+	if err != nil {
+		return err
+	}
+	return c.Reset(r.Context(), &block)
+}
+
+func queryUint(r *http.Request, name string, def uint64) (uint64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %v: %v", name, err)
+	}
+	return n, nil
 }
@@ -0,0 +1,106 @@
+package mgmt
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/playmint/ds-node/pkg/client/alchemy"
+	"github.com/playmint/ds-node/pkg/devchain"
+	"github.com/playmint/ds-node/pkg/indexer/checkpoint"
+)
+
+type testSnapshotter struct{}
+
+func (testSnapshotter) Snapshot() (*checkpoint.Checkpoint, error) {
+	return &checkpoint.Checkpoint{ChainID: 1, Block: 5}, nil
+}
+
+func TestServerRoutes(t *testing.T) {
+	tests := []struct {
+		name   string
+		server Server
+		method string
+		path   string
+		want   int
+	}{
+		{name: "snapshot disabled", method: http.MethodGet, path: "/snapshot", want: http.StatusNotFound},
+		{name: "snapshot enabled", server: Server{Snapshotter: testSnapshotter{}}, method: http.MethodGet, path: "/snapshot", want: http.StatusOK},
+		{name: "dev disabled", server: Server{Snapshotter: testSnapshotter{}}, method: http.MethodPost, path: "/dev/mine", want: http.StatusNotFound},
+		{name: "metrics", method: http.MethodGet, path: "/metrics", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.server.Handler().ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			if w.Code != tt.want {
+				t.Errorf("got status %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+// testAnvil records the params of each anvil_reset request, "" for none
+type testAnvil struct {
+	resets *[]string
+}
+
+func (a testAnvil) Reset(params *json.RawMessage) error {
+	if params == nil {
+		*a.resets = append(*a.resets, "")
+	} else {
+		*a.resets = append(*a.resets, string(*params))
+	}
+	return nil
+}
+
+type testResyncer struct{}
+
+func (testResyncer) Resync() error { return nil }
+
+func TestDevReset(t *testing.T) {
+	tests := []struct {
+		name    string
+		forkURL string
+		query   string
+		want    string
+	}{
+		{name: "fresh", want: ""},
+		{name: "fresh at a block", query: "?block=5", want: "error"},
+		{name: "fork latest", forkURL: "http://fork", want: `{"forking":{"jsonRpcUrl":"http://fork"}}`},
+		{name: "fork at a block", forkURL: "http://fork", query: "?block=5", want: `{"forking":{"blockNumber":5,"jsonRpcUrl":"http://fork"}}`},
+		{name: "other fork url", forkURL: "http://fork", query: "?url=http://other", want: `{"forking":{"jsonRpcUrl":"http://fork"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resets := []string{}
+			server := rpc.NewServer()
+			defer server.Stop()
+			if err := server.RegisterName("anvil", testAnvil{resets: &resets}); err != nil {
+				t.Fatal(err)
+			}
+			client, err := alchemy.NewClient(rpc.DialInProc(server), 1, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mgmt := Server{DevChain: devchain.New(client, tt.forkURL, testResyncer{})}
+
+			w := httptest.NewRecorder()
+			mgmt.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/dev/reset"+tt.query, nil))
+			if tt.want == "error" {
+				if w.Code != http.StatusBadRequest || len(resets) != 0 {
+					t.Fatalf("got status %d and resets %q, want a rejected request", w.Code, resets)
+				}
+				return
+			}
+			if w.Code != http.StatusNoContent {
+				t.Fatalf("got status %d: %s", w.Code, w.Body.String())
+			}
+			if len(resets) != 1 || resets[0] != tt.want {
+				t.Errorf("got resets %q, want [%q]", resets, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package scopes
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FullAccess is the scopes bitmask that grants a session every permission
+const FullAccess uint32 = 0xffffffff
+
+// Scope is a named permission granted to a session by a single bit of the
+// scopes bitmask given at signin
+type Scope struct {
+	Name string `json:"name"`
+	Bit  uint8  `json:"bit"`
+	// Actions are the 4byte selectors of the actions that require this
+	// scope. Actions not listed by any scope are not restricted.
+	Actions []string `json:"actions"`
+}
+
+// DefaultScopes mirror the scope bits sketched out in IDispatcher.sol
+var DefaultScopes = []*Scope{
+	{Name: "READ_SENSITIVE", Bit: 0},
+	{Name: "WRITE_SENSITIVE", Bit: 1},
+}
+
+// Registry maps scope bits to named permissions, either the defaults or
+// definitions specific to a game
+type Registry struct {
+	defaults []*Scope
+	games    map[common.Address][]*Scope
+}
+
+// Definitions is the format of the scopes config file
+type Definitions struct {
+	Default []*Scope            `json:"default"`
+	Games   map[string][]*Scope `json:"games"` // keyed by game id
+}
+
+func NewRegistry(defs *Definitions) (*Registry, error) {
+	r := &Registry{
+		defaults: DefaultScopes,
+		games:    map[common.Address][]*Scope{},
+	}
+	if defs == nil {
+		return r, nil
+	}
+	if err := validate(defs.Default); err != nil {
+		return nil, err
+	}
+	r.defaults = merge(DefaultScopes, defs.Default)
+	for gameID, scopes := range defs.Games {
+		if !common.IsHexAddress(gameID) {
+			return nil, fmt.Errorf("scopes: invalid game id %v", gameID)
+		}
+		if err := validate(scopes); err != nil {
+			return nil, err
+		}
+		r.games[common.HexToAddress(gameID)] = merge(r.defaults, scopes)
+	}
+	return r, nil
+}
+
+// Load reads scope definitions from a json file, an empty path returns a
+// registry with only the default scopes
+func Load(path string) (*Registry, error) {
+	if path == "" {
+		return NewRegistry(nil)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var defs Definitions
+	if err := json.Unmarshal(b, &defs); err != nil {
+		return nil, fmt.Errorf("scopes: failed to decode %v: %v", path, err)
+	}
+	return NewRegistry(&defs)
+}
+
+// Scopes returns the scope definitions that apply to the game, a nil
+// Registry only knows the default scopes
+func (r *Registry) Scopes(gameID common.Address) []*Scope {
+	if r == nil {
+		return DefaultScopes
+	}
+	if scopes, ok := r.games[gameID]; ok {
+		return scopes
+	}
+	return r.defaults
+}
+
+// Granted returns the names of the scopes in the bitmask
+func (r *Registry) Granted(gameID common.Address, bits uint32) []string {
+	names := []string{}
+	for _, scope := range r.Scopes(gameID) {
+		if bits&(1<<scope.Bit) != 0 {
+			names = append(names, scope.Name)
+		}
+	}
+	return names
+}
+
+// Check returns an error if any of the encoded actions require a scope that
+// is not in the bitmask
+func (r *Registry) Check(gameID common.Address, bits uint32, actions []string) error {
+	if bits == FullAccess {
+		return nil
+	}
+	scopes := r.Scopes(gameID)
+	for _, action := range actions {
+		b, err := hexutil.Decode(action)
+		if err != nil || len(b) < 4 {
+			return fmt.Errorf("invalid action: unable to decode selector")
+		}
+		selector := hexutil.Encode(b[:4])
+		required := []string{}
+		for _, scope := range scopes {
+			if !scope.permits(selector) {
+				continue
+			}
+			if bits&(1<<scope.Bit) != 0 {
+				required = nil
+				break
+			}
+			required = append(required, scope.Name)
+		}
+		if len(required) > 0 {
+			return fmt.Errorf("action %v requires session scope %v", selector, strings.Join(required, " or "))
+		}
+	}
+	return nil
+}
+
+func (s *Scope) permits(selector string) bool {
+	for _, action := range s.Actions {
+		if strings.EqualFold(action, selector) {
+			return true
+		}
+	}
+	return false
+}
+
+func validate(scopes []*Scope) error {
+	for _, scope := range scopes {
+		if scope.Name == "" {
+			return fmt.Errorf("scopes: missing name for bit %d", scope.Bit)
+		}
+		if scope.Bit > 31 {
+			return fmt.Errorf("scopes: bit %d for %v out of range", scope.Bit, scope.Name)
+		}
+		for _, action := range scope.Actions {
+			if b, err := hexutil.Decode(action); err != nil || len(b) != 4 {
+				return fmt.Errorf("scopes: invalid action selector %v for %v", action, scope.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// merge returns base with any scopes for the same bit replaced by those in
+// overrides
+func merge(base []*Scope, overrides []*Scope) []*Scope {
+	byBit := map[uint8]int{}
+	merged := []*Scope{}
+	for _, scopes := range [][]*Scope{base, overrides} {
+		for _, scope := range scopes {
+			if i, ok := byBit[scope.Bit]; ok {
+				merged[i] = scope
+				continue
+			}
+			byBit[scope.Bit] = len(merged)
+			merged = append(merged, scope)
+		}
+	}
+	return merged
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/playmint/ds-node/pkg/api/model"
 	"github.com/playmint/ds-node/pkg/client/alchemy"
+	"github.com/playmint/ds-node/pkg/config"
 	"github.com/playmint/ds-node/pkg/contracts/router"
 	"github.com/playmint/ds-node/pkg/contracts/state"
 	"github.com/playmint/ds-node/pkg/indexer"
@@ -142,7 +143,7 @@ func (seqr *MemorySequencer) Enqueue(
 					Str("opset", opset.Sig).
 					Uint64("nonce", actionNonce).
 					Msg("remove-stale-pending")
-				seqr.idxr.RemovePendingOpSets(map[string]bool{
+				seqr.idxr.RemovePendingOpSets(stateAddr, map[string]bool{
 					opset.Sig: true,
 				})
 			}
@@ -154,6 +155,19 @@ func (seqr *MemorySequencer) Enqueue(
 			Uint64("nonce", actionNonce).
 			Msg("action-accepted-chain")
 
+		// in dev mode SEQUENCER_MINE_EMPTY mines a block after each action
+		// so that actions are still committed when automine has been turned
+		// off. it is opt in as it mines behind the back of /dev/automine and
+		// /dev/mine, with automine on the tx is already mined anyway
+		if config.DevMode && config.SequencerMineEmpty {
+			if _, err := seqr.chainHttpClient.MineEmptyBlock(sendTimeout); err != nil {
+				seqr.log.Warn().
+					Err(err).
+					Str("hash", tx.Hash().Hex()).
+					Msg("mine-empty-fail")
+			}
+		}
+
 		maxWaitMined, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer cancel()
 		_, err = WaitMined(maxWaitMined, seqr.chainHttpClient, tx)
@@ -282,7 +296,7 @@ func (seqr *MemorySequencer) dispatchSim(
 			})
 		}
 	}
-	seqr.idxr.AddPendingOpSet(int(fakeBlockNumber), opset)
+	seqr.idxr.AddPendingOpSet(stateAddr, int(fakeBlockNumber), opset)
 	return &opset, nil
 }
 